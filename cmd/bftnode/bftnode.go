@@ -49,9 +49,10 @@ import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"flag" // Implements command-line flag parsing.
-	"fmt"  // Implements formatted I/O with functions analogous to C's printf and scanf.
-	"log"  // Implements a simple logging package.
+	"flag"    // Implements command-line flag parsing.
+	"fmt"     // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"log"     // Implements a simple logging package.
+	"strconv" // Implements conversions to and from string representations of basic data types.
 
 	// ===============
 	// Tendermint Core
@@ -71,12 +72,20 @@ func main() {
 	// Parameters
 	addrPtr := flag.String("addr", "tcp://0.0.0.0:46658", "Listen address")
 	abciPtr := flag.String("bft", "socket", "socket | grpc")
-	// persistencePtr := flag.String("persist", "", "directory to use for a database")
+	persistencePtr := flag.String("persist", "", "directory to use for a database")
+	retainPtr := flag.Uint64("retain", bft.DefaultRetainHeights, "number of past heights kept for the historical queries, 0 for all")
 	flag.Parse()
 
 	// Create the application - in memory or persisted to disk
 	var app types.Application
-	app = bft.NewBftApplication() //if *persistencePtr != "" => NewPersistentBftApplication(*persistencePtr)
+	if *persistencePtr != "" {
+		app = bft.NewPersistentBftApplication(*persistencePtr)
+	} else {
+		app = bft.NewBftApplication()
+	}
+	if log := app.SetOption(bft.OptionRetainHeights, strconv.FormatUint(*retainPtr, 10)); log != "" {
+		tendermint.Exit(log)
+	}
 
 	// Start the listener
 	srv, err := server.NewServer(*addrPtr, *abciPtr, app)
@@ -91,6 +100,9 @@ func main() {
 		// Cleanup
 		fmt.Println("Stopping service")
 		srv.Stop()
+		if persistentApp, ok := app.(*bft.PersistentBftApplication); ok {
+			persistentApp.Close()
+		}
 	})
 
 }
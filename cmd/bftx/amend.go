@@ -0,0 +1,286 @@
+// File: ./blockfreight/cmd/bftx/amend.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package main
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+	"time"          // Provides functionality for measuring and displaying time.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"github.com/urfave/cli" // Provides structure and function to build command line apps in Go.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/amendment" // Defines the amendment chain of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"     // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/bft"       // Implements the Blockfreight™ application and its state queries.
+	"github.com/blockfreight/go-bftx/lib/app/envelope"  // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"   // Defines the BFTXStore interface of the embedded stores.
+)
+
+// Propose on the application a signed BF_TX of the DB as the amendment of a BF_TX
+func cmdAmendBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return msg.Error("cli.err.args-named", "amend", 2, "BF_TX id, amendment BF_TX id")
+	}
+	if c.String("key") == "" {
+		return msg.Error("cli.err.command-needs-key", "amend")
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	bftx, err := storage.GetBfTx(db, args[1])
+	if err != nil {
+		return err
+	}
+	if !bftx.Verified {
+		return msg.Error("cli.err.not-signed")
+	}
+	if bftx.Transmitted {
+		return msg.Error("cli.err.already-transmitted")
+	}
+	transmitted := bftx
+	transmitted.Transmitted = true
+	content, err := bf_tx.BFTXContent(transmitted)
+	if err != nil {
+		return err
+	}
+
+	// The DB is not locked while the key is loaded and the amendment delivered
+	res, err := deliverAmendment(c, amendment.Request{Parent: args[0], BFTX: json.RawMessage(content)})
+	if err != nil {
+		return err
+	}
+	if res.IsErr() {
+		printAmendment(c, res, args[1])
+		return nil
+	}
+
+	// Update on DB, unless the amendment changed since it was read. The amendment is already proposed: when the
+	// update fails, it stays not transmitted on the DB
+	err = storage.ReplaceBfTx(db, bftx, content)
+	if err != nil {
+		return msg.Error("cli.err.delivered-not-saved", bftx.Id, err.Error())
+	}
+	printAmendment(c, res, args[1])
+	return nil
+}
+
+// Approve on the application the pending amendment of a BF_TX
+func cmdApproveBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return msg.Error("cli.err.args-named", "approve", 2, "BF_TX id, amendment BF_TX id")
+	}
+	if c.String("key") == "" {
+		return msg.Error("cli.err.command-needs-key", "approve")
+	}
+
+	res, err := deliverAmendment(c, amendment.Request{Parent: args[0], Id: args[1]})
+	if err != nil {
+		return err
+	}
+	printAmendment(c, res, args[1])
+	return nil
+}
+
+// deliverAmendment delivers an amendment request signed with the key of the party and commits it
+func deliverAmendment(c *cli.Context, req amendment.Request) (types.Result, error) {
+	privatekey, err := loadKey(c, c.String("key"))
+	if err != nil {
+		return types.Result{}, err
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return types.Result{}, err
+	}
+	env := envelope.New(envelope.TxAmend, uint64(time.Now().UnixNano()), payload)
+	if err := env.Sign(privatekey); err != nil {
+		return types.Result{}, err
+	}
+
+	// The application checks the sender is a party affected by the amendment
+	res := client.DeliverTxSync(env.Encode())
+	if res.IsErr() {
+		return res, nil
+	}
+	if commit := client.CommitSync(); commit.IsErr() {
+		return commit, nil
+	}
+	return res, nil
+}
+
+// printAmendment prints the result of an amendment transaction, with the id of the amendment once the application accepted it
+func printAmendment(c *cli.Context, res types.Result, id string) {
+	if res.IsErr() {
+		printResponse(c, response{
+			Code: res.Code,
+			Log:  res.Log,
+		})
+		return
+	}
+
+	//Result
+	printResponse(c, response{
+		Code:   res.Code,
+		Data:   res.Data,
+		Log:    res.Log,
+		Result: msg.T("cli.msg.bftx-id", id),
+	})
+}
+
+// Get every revision of a BF_TX from the application, from the original to the last amendment
+func cmdHistoryBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "history", 1)
+	}
+
+	// Walk back to the original, then forward to the last amendment
+	revision, err := queryRevision(args[0])
+	if err != nil {
+		return err
+	}
+	for revision.Parent != "" {
+		if revision, err = queryRevision(revision.Parent); err != nil {
+			return err
+		}
+	}
+	revisions := []amendment.Revision{revision}
+	for revision.Next != "" {
+		if revision, err = queryRevision(revision.Next); err != nil {
+			return err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	// Result
+	for _, revision := range revisions {
+		if revision.Parent == "" {
+			fmt.Println(msg.T("cli.msg.revision-original", revision.Height, revision.Id, revision.Proposer))
+			continue
+		}
+		fmt.Println(msg.T("cli.msg.revision", revision.Height, revision.Id, revision.Parent, revision.Proposer, strings.Join(revision.Changes, ", ")))
+		fmt.Println(msg.T("cli.msg.revision-approvals", strings.Join(revision.Approvals, ", ")))
+	}
+
+	resQuery, err := client.QuerySync(types.RequestQuery{Path: bft.PathPending + revision.Id})
+	if err != nil {
+		return err
+	}
+	if resQuery.Value != nil {
+		pending, err := amendment.DecodeProposal(resQuery.Value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg.T("cli.msg.revision-pending", pending.Id, pending.Parent, pending.Proposer, strings.Join(pending.Changes, ", ")))
+		fmt.Println(msg.T("cli.msg.revision-missing", strings.Join(pending.Missing(), ", ")))
+	}
+	return nil
+}
+
+// queryRevision reads the revision of a BF_TX from the application
+func queryRevision(id string) (amendment.Revision, error) {
+	resQuery, err := client.QuerySync(types.RequestQuery{Path: bft.PathRevision + id})
+	if err != nil {
+		return amendment.Revision{}, err
+	}
+	if !resQuery.Code.IsOK() {
+		return amendment.Revision{}, errors.New(resQuery.Log)
+	}
+	if resQuery.Value == nil {
+		return amendment.Revision{}, msg.Error("cli.err.revision-missing", id)
+	}
+	return amendment.DecodeRevision(resQuery.Value)
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -49,16 +49,17 @@ import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"bufio"        // Implements buffered I/O.
-	"encoding/hex" // Implements hexadecimal encoding and decoding.
-	"errors"       // Implements functions to manipulate errors.
-	"fmt"          // Implements formatted I/O with functions analogous to C's printf and scanf.
-	"io"           // Provides basic interfaces to I/O primitives.
-	"log"          // Implements a simple logging package.
-	"os"           // Provides a platform-independent interface to operating system functionality.
+	"bufio"         // Implements buffered I/O.
+	"encoding/hex"  // Implements hexadecimal encoding and decoding.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"io"            // Provides basic interfaces to I/O primitives.
+	"io/ioutil"     // Implements some I/O utility functions.
+	"log"           // Implements a simple logging package.
+	"os"            // Provides a platform-independent interface to operating system functionality.
 	"os/exec"
-	"strconv" // Implements conversions to and from string representations of basic data types.
 	"strings" // Implements simple functions to manipulate UTF-8 encoded strings.
+	"time"    // Provides functionality for measuring and displaying time.
 
 	// ====================
 	// Third-party packages
@@ -75,10 +76,17 @@ import (
 	// Blockfreight™ packages
 	// ======================
 	"github.com/blockfreight/go-bftx/build/package/version" // Defines the current version of the project.
+	"github.com/blockfreight/go-bftx/config"                // Defines the configuration of the Blockfreight™ applications.
 	"github.com/blockfreight/go-bftx/lib/app/bf_tx"         // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/bft"           // Implements the Blockfreight™ application and its state queries.
+	"github.com/blockfreight/go-bftx/lib/app/envelope"      // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle"     // Defines the lifecycle of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/title"         // Defines the title of a negotiable Bill of Lading.
 	"github.com/blockfreight/go-bftx/lib/app/validator"     // Provides functions to assure the input JSON is correct.
 	"github.com/blockfreight/go-bftx/lib/pkg/crypto"        // Provides useful functions to sign BF_TX.
-	"github.com/blockfreight/go-bftx/lib/pkg/leveldb"       // Provides some useful functions to work with LevelDB.
+	"github.com/blockfreight/go-bftx/lib/pkg/i18n"          // Provides the message catalog which localizes the messages.
+	"github.com/blockfreight/go-bftx/lib/pkg/leveldb"       // Implements the BFTXStore interface with LevelDB.
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"       // Defines the BFTXStore interface of the embedded stores.
 )
 
 // Structure for data passed to print response.
@@ -107,6 +115,14 @@ func main() {
 	//workaround for the cli library (https://github.com/urfave/cli/issues/565)
 	cli.OsExiter = func(_ int) {}
 
+	// Read the configuration and select the locale of the messages
+	var err error
+	cfg, err = loadConfig(os.Args)
+	if err != nil {
+		log.Fatal(msg.T("cli.err.config", err.Error()))
+	}
+	msg = i18n.New(selectLocale(os.Args, cfg))
+
 	app := cli.NewApp()
 	app.Name = "bftx"
 	app.Usage = "bftx [command] [args...]"
@@ -115,39 +131,57 @@ func main() {
 		cli.StringFlag{
 			Name:  "address",
 			Value: "tcp://127.0.0.1:46658",
-			Usage: "address of application socket",
+			Usage: msg.T("cli.flag.address"),
 		},
 		cli.StringFlag{
 			Name:  "call",
 			Value: "socket",
-			Usage: "socket or grpc",
+			Usage: msg.T("cli.flag.call"),
 		},
 		cli.BoolFlag{
 			Name:  "verbose",
-			Usage: "print the command and results as if it were a console session",
+			Usage: msg.T("cli.flag.verbose"),
+		},
+		cli.StringFlag{
+			Name:   "lang",
+			Usage:  msg.T("cli.flag.lang", strings.Join(i18n.Supported(), ", ")),
+			EnvVar: langEnv,
+		},
+		cli.StringFlag{
+			Name:  "config",
+			Value: config.DefaultPath,
+			Usage: msg.T("cli.flag.config"),
+		},
+		cli.StringFlag{
+			Name:  "keystore",
+			Value: "bft-keys",
+			Usage: msg.T("cli.flag.keystore"),
+		},
+		cli.StringFlag{
+			Name:  "schema",
+			Usage: msg.T("cli.flag.schema", validator.DefaultSchema),
+		},
+		cli.StringFlag{
+			Name:  "db",
+			Usage: msg.T("cli.flag.db", leveldb.DefaultPath),
 		},
-		/*cli.StringFlag{
-		    Name: "lang",
-		    Value: "english",
-		    Usage: "language for the greeting",
-		},*/
 		cli.StringFlag{
 			Name:  "json_path, jp",
 			Value: "./examples/",
-			Usage: "define the source path where the json is",
+			Usage: msg.T("cli.flag.json_path"),
 		},
 	}
 	app.Commands = []cli.Command{
 		{
 			Name:  "batch",
-			Usage: "Run a batch of Blockfreight™ commands against an application",
+			Usage: msg.T("cli.cmd.batch"),
 			Action: func(c *cli.Context) error {
 				return cmdBatch(app, c)
 			},
 		},
 		{
 			Name:  "console",
-			Usage: "Start an interactive Blockfreight™ console for multiple commands",
+			Usage: msg.T("cli.cmd.console"),
 			Action: func(c *cli.Context) error {
 				return cmdConsole(app, c)
 			},
@@ -161,127 +195,411 @@ func main() {
 		},*/
 		{
 			Name:  "info",
-			Usage: "Get some info about the application (Parameters: none)",
+			Usage: msg.T("cli.cmd.info"),
 			Action: func(c *cli.Context) error {
 				return cmdInfo(c)
 			},
 		},
 		{
 			Name:  "set_option",
-			Usage: "Set an option on the application (Parameters: --Global Options, value)",
+			Usage: msg.T("cli.cmd.set_option"),
 			Action: func(c *cli.Context) error {
 				return cmdSetOption(c)
 			},
 		},
 		{
 			Name:  "verify",
-			Usage: "Verify the JSON imput against a BF_TX (Parameters: JSON Filepath)",
+			Usage: msg.T("cli.cmd.verify"),
 			Action: func(c *cli.Context) error {
 				return cmdVerifyBfTx(c) //cmdCheckBfTx
 			},
 		},
 		{
 			Name:  "validate",
-			Usage: "Validate a BF_TX (Parameters: JSON Filepath)",
+			Usage: msg.T("cli.cmd.validate"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format",
+					Value: "text",
+					Usage: msg.T("cli.flag.format"),
+				},
+			},
 			Action: func(c *cli.Context) error {
 				return cmdValidateBfTx(c)
 			},
 		},
 		{
 			Name:  "construct",
-			Usage: "Construct a new BF_TX (Parameters: JSON Filepath)",
+			Usage: msg.T("cli.cmd.construct"),
 			Action: func(c *cli.Context) error {
 				return cmdConstructBfTx(c)
 			},
 		},
 		{
 			Name:  "sign",
-			Usage: "Sign a new BF_TX (Parameters: BF_TX id)",
+			Usage: msg.T("cli.cmd.sign"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key",
+					Usage: msg.T("cli.flag.key"),
+				},
+				cli.StringFlag{
+					Name:  "algorithm",
+					Value: crypto.DefaultAlgorithm,
+					Usage: msg.T("cli.flag.algorithm", crypto.AlgorithmSHA256, crypto.AlgorithmSHA3),
+				},
+			},
 			Action: func(c *cli.Context) error {
 				return cmdSignBfTx(c)
 			},
 		},
+		{
+			Name:  "verify-signature",
+			Usage: msg.T("cli.cmd.verify-signature"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "pubkey",
+					Usage: msg.T("cli.flag.pubkey"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdVerifySignatureBfTx(c)
+			},
+		},
+		{
+			Name:  "keys",
+			Usage: msg.T("cli.cmd.keys"),
+			Subcommands: []cli.Command{
+				{
+					Name:  "create",
+					Usage: msg.T("cli.cmd.keys.create"),
+					Action: func(c *cli.Context) error {
+						return cmdKeysCreate(c)
+					},
+				},
+				{
+					Name:  "import",
+					Usage: msg.T("cli.cmd.keys.import"),
+					Action: func(c *cli.Context) error {
+						return cmdKeysImport(c)
+					},
+				},
+				{
+					Name:  "list",
+					Usage: msg.T("cli.cmd.keys.list"),
+					Action: func(c *cli.Context) error {
+						return cmdKeysList(c)
+					},
+				},
+				{
+					Name:  "delete",
+					Usage: msg.T("cli.cmd.keys.delete"),
+					Action: func(c *cli.Context) error {
+						return cmdKeysDelete(c)
+					},
+				},
+			},
+		},
 		{
 			Name:  "broadcast",
-			Usage: "Deliver a new BF_TX to application (Parameters: BF_TX id)",
+			Usage: msg.T("cli.cmd.broadcast"),
 			Action: func(c *cli.Context) error {
 				return cmdBroadcastBfTx(c)
 			},
 		},
 		{
 			Name:  "commit",
-			Usage: "Commit the application state and return the Merkle root hash (Parameters: none)",
+			Usage: msg.T("cli.cmd.commit"),
 			Action: func(c *cli.Context) error {
 				return cmdCommit(c)
 			},
 		},
-		/* {
-		    Name:  "query",
-		    Usage: "Query application state",
-		    Action: func(c *cli.Context) error {
-		        return cmdQuery(c)
-		    },
-		},*/
+		{
+			Name:  "query",
+			Usage: msg.T("cli.cmd.query"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "data",
+					Usage: msg.T("cli.flag.data"),
+				},
+				cli.Uint64Flag{
+					Name:  "height",
+					Usage: msg.T("cli.flag.height"),
+				},
+				cli.BoolFlag{
+					Name:  "prove",
+					Usage: msg.T("cli.flag.prove"),
+				},
+				cli.StringFlag{
+					Name:  "app-hash",
+					Usage: msg.T("cli.flag.app-hash"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdQuery(c)
+			},
+		},
 		{
 			Name:  "get",
-			Usage: "Retrieve a [BF_TX] by its ID (Parameters: BF_TX id)",
+			Usage: msg.T("cli.cmd.get"),
 			Action: func(c *cli.Context) error {
 				return cmdGetBfTx(c)
 			},
 		},
 		{
 			Name:  "append",
-			Usage: "Append a new BF_TX to an existing BF_TX (Parameters: JSON Filepath, BF_TX id)",
+			Usage: msg.T("cli.cmd.append"),
 			Action: func(c *cli.Context) error {
 				return cmdAppendBfTx(c)
 			},
 		},
+		{
+			Name:  "amend",
+			Usage: msg.T("cli.cmd.amend"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key",
+					Usage: msg.T("cli.flag.key"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdAmendBfTx(c)
+			},
+		},
+		{
+			Name:  "approve",
+			Usage: msg.T("cli.cmd.approve"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key",
+					Usage: msg.T("cli.flag.key"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdApproveBfTx(c)
+			},
+		},
+		{
+			Name:  "history",
+			Usage: msg.T("cli.cmd.history"),
+			Action: func(c *cli.Context) error {
+				return cmdHistoryBfTx(c)
+			},
+		},
 		{
 			Name:  "state",
-			Usage: "Get the current state of a determined BF_TX (Parameters: BF_TX id)",
+			Usage: msg.T("cli.cmd.state"),
 			Action: func(c *cli.Context) error {
 				return cmdStateBfTx(c)
 			},
 		},
+		{
+			Name:  "transition",
+			Usage: msg.T("cli.cmd.transition", stateNames()),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key",
+					Usage: msg.T("cli.flag.key"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdTransitionBfTx(c)
+			},
+		},
+		{
+			Name:  "lifecycle",
+			Usage: msg.T("cli.cmd.lifecycle"),
+			Action: func(c *cli.Context) error {
+				return cmdLifecycleBfTx(c)
+			},
+		},
+		{
+			Name:  "transfer",
+			Usage: msg.T("cli.cmd.transfer"),
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "key",
+					Usage: msg.T("cli.flag.key"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdTransferBfTx(c)
+			},
+		},
+		{
+			Name:  "holder",
+			Usage: msg.T("cli.cmd.holder"),
+			Action: func(c *cli.Context) error {
+				return cmdHolderBfTx(c)
+			},
+		},
 		{
 			Name:  "total",
-			Usage: "Query the total of BF_TX in DB (Parameters: none)",
+			Usage: msg.T("cli.cmd.total"),
 			Action: func(c *cli.Context) error {
 				return cmdTotalBfTx(c)
 			},
 		},
+		{
+			Name:  "list",
+			Usage: msg.T("cli.cmd.list"),
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "offset",
+					Usage: msg.T("cli.flag.offset"),
+				},
+				cli.IntFlag{
+					Name:  "limit",
+					Value: 20,
+					Usage: msg.T("cli.flag.limit"),
+				},
+				cli.StringFlag{
+					Name:  "cursor",
+					Usage: msg.T("cli.flag.cursor"),
+				},
+				cli.StringFlag{
+					Name:  "state",
+					Usage: msg.T("cli.flag.state"),
+				},
+				cli.StringFlag{
+					Name:  "sort",
+					Value: storage.SortId,
+					Usage: msg.T("cli.flag.sort", storage.SortId, storage.SortDate, storage.SortBol),
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Value: "table",
+					Usage: msg.T("cli.flag.list-format"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdListBfTx(c)
+			},
+		},
+		{
+			Name:  "search",
+			Usage: msg.T("cli.cmd.search", strings.Join(storage.Fields, ", ")),
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "offset",
+					Usage: msg.T("cli.flag.offset"),
+				},
+				cli.IntFlag{
+					Name:  "limit",
+					Value: 20,
+					Usage: msg.T("cli.flag.limit"),
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Value: "text",
+					Usage: msg.T("cli.flag.search-format"),
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return cmdSearchBfTx(c)
+			},
+		},
+		{
+			Name:  "rebuild-index",
+			Usage: msg.T("cli.cmd.rebuild-index"),
+			Action: func(c *cli.Context) error {
+				return cmdRebuildIndex(c)
+			},
+		},
+		{
+			Name:  "db",
+			Usage: msg.T("cli.cmd.db"),
+			Subcommands: []cli.Command{
+				{
+					Name:  "export",
+					Usage: msg.T("cli.cmd.db.export"),
+					Action: func(c *cli.Context) error {
+						return cmdDbExport(c)
+					},
+				},
+				{
+					Name:  "import",
+					Usage: msg.T("cli.cmd.db.import"),
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "on-conflict",
+							Value: storage.ConflictFail,
+							Usage: msg.T("cli.flag.on-conflict", storage.ConflictSkip, storage.ConflictOverwrite, storage.ConflictFail),
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return cmdDbImport(c)
+					},
+				},
+				{
+					Name:  "migrate",
+					Usage: msg.T("cli.cmd.db.migrate"),
+					Flags: []cli.Flag{
+						cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: msg.T("cli.flag.dry-run"),
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return cmdDbMigrate(c)
+					},
+				},
+				{
+					Name:  "snapshot",
+					Usage: msg.T("cli.cmd.db.snapshot"),
+					Action: func(c *cli.Context) error {
+						return cmdDbSnapshot(c)
+					},
+				},
+			},
+		},
 		{
 			Name:  "echo",
-			Usage: "Print clearly a BF_TX (Parameters: BF_TX id)",
+			Usage: msg.T("cli.cmd.echo"),
 			Action: func(c *cli.Context) error {
 				return cmdPrintBfTx(c)
 			},
 		},
 		{
 			Name:  "python",
-			Usage: "Test Python Hello Function",
+			Usage: msg.T("cli.cmd.python"),
 			Action: func(c *cli.Context) error {
 				return cmdHelloPython(c)
 			},
 		},
 		{
 			Name:  "exit",
-			Usage: "Leaves the program. (Parameters: none)",
+			Usage: msg.T("cli.cmd.exit"),
 			Action: func(c *cli.Context) {
 				os.Exit(0)
 			},
 		},
 	}
 	app.Before = before
-	err := app.Run(os.Args)
+	err = app.Run(os.Args)
+	if closeErr := closeStore(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
 }
 
+// offlineCommands do not need a connection to the application.
+var offlineCommands = map[string]bool{
+	"validate":      true,
+	"list":          true,
+	"search":        true,
+	"rebuild-index": true,
+	"db":            true,
+}
+
 func before(c *cli.Context) error {
 	introduction(c)
+	if offlineCommands[c.Args().First()] {
+		return nil
+	}
 	if client == nil {
 		var err error
 		client, err = abcicli.NewClient(c.GlobalString("address"), c.GlobalString("call"), false)
@@ -294,8 +612,8 @@ func before(c *cli.Context) error {
 
 // badCmd is called when we invoke with an invalid first argument (just for console for now)
 func badCmd(c *cli.Context, cmd string) {
-	fmt.Println("Unknown command:", cmd)
-	fmt.Println("Please try one of the following:")
+	fmt.Println(msg.T("cli.err.unknown-command", cmd))
+	fmt.Println(msg.T("cli.err.try"))
 	fmt.Println("")
 	cli.DefaultAppComplete(c)
 }
@@ -316,27 +634,7 @@ func persistentArgs(line []byte) []string {
 
 //--------------------------------------------------------------------------------
 
-func cmdGenerateBftxID(bftx bf_tx.BF_TX) ([]byte, error) {
-	// BlockID defines the unique ID of a block as its Hash and its PartSetHeader
-	salt, err := getBlockAppHash()
-	if err != nil {
-		return nil, err
-	}
-
-	// Hash BF_TX Object
-	hash, err := bf_tx.HashBFTX(bftx)
-	if err != nil {
-		return nil, err
-	}
-
-	// Generate BF_TX id
-	bftxID := bf_tx.GenerateBFTXSalt(hash, salt)
-
-	//printResponse (blah blah)
-
-	return bftxID, nil
-}
-
+// getBlockAppHash returns the app hash of the last block, the chain state which salts the BF_TX ids.
 func getBlockAppHash() ([]byte, error) {
 	resInfo, err := client.InfoSync()
 	if err != nil {
@@ -351,7 +649,7 @@ func cmdBatch(app *cli.App, c *cli.Context) error {
 	for {
 		line, more, err := bufReader.ReadLine()
 		if more {
-			return errors.New("Input line is too long")
+			return msg.Error("cli.err.line-too-long")
 		} else if err == io.EOF {
 			break
 		} else if len(line) == 0 {
@@ -375,7 +673,7 @@ func cmdConsole(app *cli.App, c *cli.Context) error {
 		bufReader := bufio.NewReader(os.Stdin)
 		line, more, err := bufReader.ReadLine()
 		if more {
-			return errors.New("Input is too long")
+			return msg.Error("cli.err.input-too-long")
 		} else if err != nil {
 			return err
 		}
@@ -419,7 +717,7 @@ func cmdInfo(c *cli.Context) error {
 func cmdSetOption(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 2 {
-		return errors.New("Command set_option takes 2 arguments (key, value)")
+		return msg.Error("cli.err.args-named", "set_option", 2, "key, value")
 	}
 	resSetOption := client.SetOptionSync(args[0], args[1])
 	printResponse(c, response{
@@ -432,7 +730,7 @@ func cmdSetOption(c *cli.Context) error {
 func cmdVerifyBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command verify takes 1 argument")
+		return msg.Error("cli.err.args", "verify", 1)
 	}
 
 	// Read JSON and instance the BF_TX structure
@@ -441,23 +739,31 @@ func cmdVerifyBfTx(c *cli.Context) error {
 		return err
 	}
 
-	// Get the BF_TX old_content in string format
-	jcontent, err := bf_tx.BFTXContent(jbftx)
+	// Get the Bill of Lading canonical content
+	jcontent, err := bf_tx.CanonicalContent(jbftx)
 	if err != nil {
 		return err
 	}
 
-	result, err := leveldb.Verify(jcontent)
+	// Look for the BF_TX with the same content
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+	result, err := storage.Verify(db, jcontent)
+	if err == storage.ErrIndexMissing {
+		return msg.Error("cli.err.index-missing")
+	}
 	if err != nil {
 		return err
 	}
 	if result == nil {
-		return errors.New("JSON content does not have a BF_TX associated.")
+		return msg.Error("cli.err.no-associated-bftx")
 	}
 
 	// Result
 	printResponse(c, response{
-		Result: "The BF_TX associated to JSON content is " + string(result),
+		Result: msg.T("cli.msg.associated-bftx", string(result)),
 	})
 
 	/*printResponse(c, response{
@@ -472,71 +778,102 @@ func cmdVerifyBfTx(c *cli.Context) error {
 func cmdValidateBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command validate takes 1 argument")
+		return msg.Error("cli.err.args", "validate", 1)
 	}
 
-	// Read JSON and instance the BF_TX structure
-	bftx, err := bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
+	// Validate the JSON against the schema and the BF_TX fields
+	result, err := validateFile(c, c.GlobalString("json_path")+args[0])
 	if err != nil {
 		return err
 	}
+	result = result.Localize(msg)
+
+	switch c.String("format") {
+	case "json":
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	case "text":
+		printIssues(result)
+		if result.Valid {
+			printResponse(c, response{
+				Result: msg.T("cli.msg.valid", len(result.Warnings())),
+			})
+		}
+	default:
+		return msg.Error("cli.err.unknown-format", c.String("format"))
+	}
+	return result.Err()
+}
 
-	// Validate the BF_TX
-	result, err := validator.ValidateBFTX(bftx)
+// validateFile validates the JSON file against the schema given by --schema or the configuration and, when it
+// matches, the BF_TX fields.
+func validateFile(c *cli.Context, path string) (validator.ValidationResult, error) {
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		fmt.Println(result)
-		return err
+		return validator.ValidationResult{}, err
 	}
+	schema := c.GlobalString("schema")
+	if schema == "" {
+		schema = cfg.Schema
+	}
+	return validator.ValidateDocument(validator.SchemaPath(schema), data)
+}
 
-	// Result
-	printResponse(c, response{
-		Result: result,
-	})
-	return nil
+// printIssues prints every issue of a validation result, one per line.
+func printIssues(result validator.ValidationResult) {
+	for _, issue := range result.Issues {
+		fmt.Println(issue.Format(msg))
+	}
 }
 
 // Construct the Blockfreight™ Transaction [BF_TX]
 func cmdConstructBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command construct takes 1 argument")
+		return msg.Error("cli.err.args", "construct", 1)
 	}
 
-	// Read JSON and instance the BF_TX structure
-	bftx, err := bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
+	// Validate the JSON against the schema and the BF_TX fields
+	result, err := validateFile(c, c.GlobalString("json_path")+args[0])
 	if err != nil {
 		return err
 	}
+	result = result.Localize(msg)
+	printIssues(result)
+	if err := result.Err(); err != nil {
+		return err
+	}
 
-	newId, err := cmdGenerateBftxID(bftx)
+	// Read JSON and instance the BF_TX structure
+	bftx, err := bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
 	if err != nil {
 		return err
 	}
 
-	bftx.Id = fmt.Sprintf("%x", newId)
-
-	// Re-validate a BF_TX before create a BF_TX
-	result, err := validator.ValidateBFTX(bftx)
+	// BlockID defines the unique ID of a block as its Hash and its PartSetHeader
+	salt, err := getBlockAppHash()
 	if err != nil {
-		fmt.Println(result)
 		return err
 	}
 
-	// Get the BF_TX content in string format
-	content, err := bf_tx.BFTXContent(bftx)
+	// Open the DB
+	db, err := openStore(c)
 	if err != nil {
 		return err
 	}
 
-	// Save on DB
-	err = leveldb.RecordOnDB(bftx.Id, content)
+	// Save on DB under a new BF_TX id
+	id, err := storage.ConstructBfTx(db, bftx, salt)
 	if err != nil {
 		return err
 	}
 
 	// Result
 	printResponse(c, response{
-		Result: "BF_TX Id: " + bftx.Id,
+		Result: msg.T("cli.msg.bftx-id", id),
 	})
 
 	return nil
@@ -546,39 +883,114 @@ func cmdConstructBfTx(c *cli.Context) error {
 func cmdSignBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command sign takes 1 argument")
+		return msg.Error("cli.err.args", "sign", 1)
+	}
+	if c.String("key") == "" {
+		return msg.Error("cli.err.need-key")
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
 	}
 
 	// Get a BF_TX by id
-	bftx, err := leveldb.GetBfTx(args[0])
+	bftx, err := storage.GetBfTx(db, args[0])
 	if err != nil {
 		return err
 	}
-	if bftx.Verified {
-		return errors.New("BF_TX already signed.")
+	// Weak legacy signatures can be renewed
+	if bftx.Verified && !crypto.WeakSignature(bftx) {
+		return msg.Error("cli.err.already-signed")
+	}
+
+	// Load the signing key from the keystore. It may prompt for the passphrase, so the DB is not locked meanwhile
+	privatekey, err := loadKey(c, c.String("key"))
+	if err != nil {
+		return err
 	}
 
 	// Sign BF_TX
-	bftx, err = crypto.SignBFTX(bftx)
+	signed, err := crypto.SignBFTXWithAlgorithm(bftx, privatekey, c.String("algorithm"))
 	if err != nil {
 		return err
 	}
 
 	// Get the BF_TX content in string format
-	content, err := bf_tx.BFTXContent(bftx)
+	content, err := bf_tx.BFTXContent(signed)
 	if err != nil {
 		return err
 	}
 
-	// Update on DB
-	err = leveldb.RecordOnDB(string(bftx.Id), content)
+	// Update on DB, unless the BF_TX changed since it was read
+	err = storage.ReplaceBfTx(db, bftx, content)
+	if err == storage.ErrChanged {
+		return msg.Error("cli.err.bftx-changed", args[0])
+	}
 	if err != nil {
 		return err
 	}
 
 	// Result
 	printResponse(c, response{
-		Result: "BF_TX signed",
+		Result: msg.T("cli.msg.signed"),
+	})
+	return nil
+}
+
+// Verify the signature of a BF_TX, read from the DB or from a JSON file
+func cmdVerifySignatureBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "verify-signature", 1)
+	}
+
+	// Get the BF_TX by id, or from a JSON file
+	var bftx bf_tx.BF_TX
+	var err error
+	if strings.HasSuffix(args[0], ".json") {
+		bftx, err = bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
+	} else {
+		var db storage.BFTXStore
+		if db, err = openStore(c); err == nil {
+			bftx, err = storage.GetBfTx(db, args[0])
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	// Public key to verify against
+	signer := bftx.PublicKey
+	if c.String("pubkey") != "" {
+		signer = c.String("pubkey")
+	}
+	pubkey, err := crypto.ParsePublicKey(signer)
+	if err != nil {
+		return err
+	}
+
+	err = crypto.VerifyBFTX(bftx, pubkey)
+	if err != nil {
+		return err
+	}
+
+	// Report the signer, with its keystore name when it is a local key
+	result := msg.T("cli.msg.signature-valid", crypto.EncodePublicKey(pubkey))
+	if ks, err := openKeystore(c); err == nil {
+		if name, err := ks.Find(crypto.EncodePublicKey(pubkey)); err == nil {
+			result += msg.T("cli.msg.signer-key", name)
+		}
+	}
+	result += msg.T("cli.msg.signed-with", crypto.SignatureAlgorithm(bftx))
+	if crypto.WeakSignature(bftx) {
+		result += msg.T("cli.msg.weak-signature")
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: result,
 	})
 	return nil
 }
@@ -587,38 +999,79 @@ func cmdSignBfTx(c *cli.Context) error {
 func cmdBroadcastBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command broadcast takes 1 argument")
+		return msg.Error("cli.err.args", "broadcast", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
 	}
 
 	// Get a BF_TX by id
-	bftx, err := leveldb.GetBfTx(args[0])
+	bftx, err := storage.GetBfTx(db, args[0])
 	if err != nil {
 		return err
 	}
 	if !bftx.Verified {
-		return errors.New("BF_TX is not signed yet.")
+		return msg.Error("cli.err.not-signed")
 	}
 	if bftx.Transmitted {
-		return errors.New("BF_TX already transmitted.")
+		return msg.Error("cli.err.already-transmitted")
 	}
 
 	// Change the boolean valud for Transmitted attribute
-	bftx.Transmitted = true
+	transmitted := bftx
+	transmitted.Transmitted = true
 
 	// Get the BF_TX content in string format
-	content, err := bf_tx.BFTXContent(bftx)
+	content, err := bf_tx.BFTXContent(transmitted)
 	if err != nil {
 		return err
 	}
 
-	// Update on DB
-	err = leveldb.RecordOnDB(string(bftx.Id), content)
+	// Load the key of the BF_TX signer from the keystore. It may prompt for the passphrase, so the DB is not locked
+	// meanwhile, nor while the BF_TX is delivered
+	ks, err := openKeystore(c)
+	if err != nil {
+		return err
+	}
+	name, err := ks.Find(bftx.PublicKey)
+	if err != nil {
+		return msg.Error("cli.err.signer-not-in-keystore")
+	}
+	privatekey, err := loadKey(c, name)
 	if err != nil {
 		return err
 	}
 
-	// Deliver / Publish a BF_TX
-	res := client.DeliverTxSync([]byte(content))
+	// Wrap the BF_TX in a create envelope signed by the BF_TX signer
+	env := envelope.New(envelope.TxCreate, uint64(time.Now().UnixNano()), []byte(content))
+	err = env.Sign(privatekey)
+	if err != nil {
+		return err
+	}
+
+	// Deliver / Publish a BF_TX. A BF_TX already on the application with the same content was delivered by an earlier
+	// broadcast which failed to mark it as transmitted, so it is only marked now
+	res := client.DeliverTxSync(env.Encode())
+	if res.Code == bft.ErrBftxDuplicate.Code && deliveredBfTx(bftx.Id, content) {
+		res = types.NewResultOK([]byte(bftx.Id), "")
+	}
+	if res.IsErr() {
+		printResponse(c, response{
+			Code: res.Code,
+			Log:  res.Log,
+		})
+		return nil
+	}
+
+	// Update on DB, unless the BF_TX changed since it was read. The BF_TX is already on the application: when the
+	// update fails, it stays not transmitted on the DB until it is broadcast again
+	err = storage.ReplaceBfTx(db, bftx, content)
+	if err != nil {
+		return msg.Error("cli.err.delivered-not-saved", bftx.Id, err.Error())
+	}
 
 	// Check the BF_TX hash
 	res = client.CommitSync()
@@ -644,50 +1097,92 @@ func cmdCommit(c *cli.Context) error {
 	return nil
 }
 
+// deliveredBfTx tells whether the application holds the BF_TX id with exactly the given content
+func deliveredBfTx(id, content string) bool {
+	resQuery, err := client.QuerySync(types.RequestQuery{Path: bft.PathBftx + id})
+	if err != nil || !resQuery.Code.IsOK() {
+		return false
+	}
+	return string(resQuery.Value) == content
+}
+
 // Query application state
-// TODO JCNM: Make request and response support all fields.
-/*func cmdQuery(c *cli.Context) error {
-    args := c.Args()
-    if len(args) != 1 {
-        return errors.New("Command query takes 1 argument")
-    }
-
-    // TODO JCNM: Check the query because when the bf_tx is added to the blockchain, it is signed. But, in here is not signed. Them, doesn't find match
-    // TODO JCNM: Query from blockchain
-    bft_tx := bf_tx.SetBFTX(c.GlobalString("json_path")+string(args[0]))
-    queryBytes := []byte(bf_tx.BFTXContent(bft_tx))
-
-    resQuery, err := client.QuerySync(types.RequestQuery{
-        Data:   queryBytes,
-        Path:   "/block", // TODO expose
-        Height: 0,        // TODO expose
-        //Prove:  true,     // TODO expose
-    })
-    if err != nil {
-        return err
-    }
-    printResponse(c, response{
-        Code: resQuery.Code,
-        Log:  resQuery.Log,
-        Query: &queryResponse{
-            Key:    resQuery.Key,
-            Value:  resQuery.Value,
-            Height: resQuery.Height,
-            Proof:  resQuery.Proof,
-        },
-    })
-    return nil
-}*/
+func cmdQuery(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "query", 1)
+	}
+
+	// The proof is verified locally against the given app hash
+	appHash, err := hex.DecodeString(c.String("app-hash"))
+	if err != nil {
+		return msg.Error("cli.err.hex-argument", err.Error())
+	}
+
+	reqQuery := types.RequestQuery{
+		Path:   args[0],
+		Data:   []byte(c.String("data")),
+		Height: c.Uint64("height"),
+		Prove:  c.Bool("prove") || len(appHash) != 0,
+	}
+	resQuery, err := client.QuerySync(reqQuery)
+	if err != nil {
+		return err
+	}
+	rsp := response{
+		Code: resQuery.Code,
+		Log:  resQuery.Log,
+		Query: &queryResponse{
+			Key:    resQuery.Key,
+			Value:  resQuery.Value,
+			Height: resQuery.Height,
+			Proof:  resQuery.Proof,
+		},
+	}
+	if len(appHash) == 0 || !resQuery.Code.IsOK() {
+		printResponse(c, rsp)
+		return nil
+	}
+
+	err = verifyQuery(reqQuery, resQuery, appHash)
+	if err == nil {
+		rsp.Result = msg.T("cli.msg.proof-valid", appHash)
+	}
+	printResponse(c, rsp)
+	switch err {
+	case nil:
+		return nil
+	case bft.ErrProofMissing:
+		return msg.Error("cli.err.proof-missing")
+	default:
+		return msg.Error("cli.err.proof-invalid")
+	}
+}
+
+// verifyQuery checks the proof of a query response against an app hash. The proof of /bftx/by-bol/{num} covers
+// the bill of lading number too.
+func verifyQuery(reqQuery types.RequestQuery, resQuery types.ResponseQuery, appHash []byte) error {
+	if strings.HasPrefix(reqQuery.Path, bft.PathBol) {
+		return bft.VerifyBolProof(strings.TrimPrefix(reqQuery.Path, bft.PathBol), resQuery.Key, resQuery.Value, resQuery.Proof, appHash)
+	}
+	return bft.VerifyProof(resQuery.Key, resQuery.Value, resQuery.Proof, appHash)
+}
 
 // Return the output JSON
 func cmdGetBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command get takes 1 argument")
+		return msg.Error("cli.err.args", "get", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
 	}
 
 	// Get a BF_TX by id
-	bftx, err := leveldb.GetBfTx(args[0])
+	bftx, err := storage.GetBfTx(db, args[0])
 	if err != nil {
 		return err
 	}
@@ -709,110 +1204,376 @@ func cmdGetBfTx(c *cli.Context) error {
 func cmdAppendBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 2 {
-		return errors.New("Command append takes 2 arguments")
+		return msg.Error("cli.err.args", "append", 2)
 	}
 
-	// Get a BF_TX by id
-	oldBftx, err := leveldb.GetBfTx(args[1])
+	// Read JSON and instance the BF_TX structure
+	newBftx, err := bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
 	if err != nil {
 		return err
 	}
 
-	// Query the total of BF_TX in DB
-	// n, err := leveldb.Total()
+	// BlockID defines the unique ID of a block as its Hash and its PartSetHeader
+	salt, err := getBlockAppHash()
 	if err != nil {
 		return err
 	}
 
-	// Read JSON and instance the BF_TX structure
-	newBftx, err := bf_tx.SetBFTX(c.GlobalString("json_path") + args[0])
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	// Save the new BF_TX under a new id and link the old one to it, together
+	id, err := storage.AppendBfTx(db, args[1], newBftx, salt)
+	if err == storage.ErrAmended {
+		return msg.Error("cli.err.already-amended", args[1])
+	}
 	if err != nil {
 		return err
 	}
 
-	// Set the BF_TX id
-	//newBftx.Id = n+1     //TODO JCNM: Solve concurrency problem
+	//Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.bftx-id", id),
+	})
 
-	// Update the BF_TX appended attribute of the old BF_TX
-	oldBftx.Amendment = newBftx.Id
+	return nil
+}
 
-	// Get the BF_TX (old and new) content in string format
-	newContent, err := bf_tx.BFTXContent(newBftx)
+// Get the current state of a determined BF_TX
+func cmdStateBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "state", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
 	if err != nil {
 		return err
 	}
-	oldContent, err := bf_tx.BFTXContent(oldBftx)
+
+	// Get a BF_TX by id
+	bftx, err := storage.GetBfTx(db, args[0])
 	if err != nil {
 		return err
 	}
 
-	// Save on DB
-	err = leveldb.RecordOnDB(string(newBftx.Id), newContent)
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.state", bf_tx.State(bftx)),
+	})
+	return nil
+}
+
+func cmdPrintBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "print", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
 	if err != nil {
 		return err
 	}
 
-	// Update on DB
-	err = leveldb.RecordOnDB(string(oldBftx.Id), oldContent)
+	// Get a BF_TX by id
+	bftx, err := storage.GetBfTx(db, args[0])
 	if err != nil {
 		return err
 	}
 
+	// Print the BF_TX clearly
+	bf_tx.PrintBFTX(bftx)
+	return nil
+}
+
+// Move a BF_TX to another state of its lifecycle on the application
+func cmdTransitionBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return msg.Error("cli.err.args-named", "transition", 2, "BF_TX id, state")
+	}
+	state, err := lifecycle.Parse(args[1])
+	if err != nil {
+		return msg.Error("cli.err.unknown-state", args[1], stateNames())
+	}
+	if c.String("key") == "" {
+		return msg.Error("cli.err.command-needs-key", "transition")
+	}
+
+	// Load the key of the party which makes the transition from the keystore
+	privatekey, err := loadKey(c, c.String("key"))
+	if err != nil {
+		return err
+	}
+
+	// Wrap the transition in an envelope signed by the party
+	payload, err := json.Marshal(lifecycle.Request{Id: args[0], State: state})
+	if err != nil {
+		return err
+	}
+	env := envelope.New(envelope.TxTransition, uint64(time.Now().UnixNano()), payload)
+	if err := env.Sign(privatekey); err != nil {
+		return err
+	}
+
+	// The application checks the transition and the role of the party
+	res := client.DeliverTxSync(env.Encode())
+	if res.IsErr() {
+		printResponse(c, response{
+			Code: res.Code,
+			Log:  res.Log,
+		})
+		return nil
+	}
+	res = client.CommitSync()
+
 	//Result
 	printResponse(c, response{
-		Result: "BF_TX Id: " + string(newBftx.Id),
+		Code:   res.Code,
+		Data:   res.Data,
+		Log:    res.Log,
+		Result: msg.T("cli.msg.state", state),
 	})
-
 	return nil
 }
 
-// Get the current state of a determined BF_TX
-func cmdStateBfTx(c *cli.Context) error {
+// Get the lifecycle state and history of a BF_TX from the application
+func cmdLifecycleBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command state takes 1 argument")
+		return msg.Error("cli.err.args", "lifecycle", 1)
 	}
 
-	// Get a BF_TX by id
-	bftx, err := leveldb.GetBfTx(args[0])
+	resQuery, err := client.QuerySync(types.RequestQuery{Path: bft.PathLifecycle + args[0]})
+	if err != nil {
+		return err
+	}
+	if !resQuery.Code.IsOK() {
+		printResponse(c, response{
+			Code: resQuery.Code,
+			Log:  resQuery.Log,
+		})
+		return nil
+	}
+	if resQuery.Value == nil {
+		return msg.Error("cli.err.lifecycle-missing", args[0])
+	}
+	record, err := lifecycle.DecodeRecord(resQuery.Value)
 	if err != nil {
 		return err
 	}
 
 	// Result
 	printResponse(c, response{
-		Result: "BF_TX state: " + bf_tx.State(bftx),
+		Result: msg.T("cli.msg.state", record.State),
 	})
+	for _, entry := range record.History {
+		fmt.Println(msg.T("cli.msg.lifecycle-entry", entry.Height, entry.To, entry.By))
+	}
 	return nil
 }
 
-func cmdPrintBfTx(c *cli.Context) error {
+// Transfer the title of a BF_TX on the application: endorse it to a party, in blank to a bearer, or surrender it
+func cmdTransferBfTx(c *cli.Context) error {
+	args := c.Args()
+	if len(args) < 2 {
+		return msg.Error("cli.err.args-named", "transfer", 3, "BF_TX id, endorse|blank|surrender, endorsee public key")
+	}
+	req := title.Request{Id: args[0], Kind: title.Kind(args[1])}
+	switch req.Kind {
+	case title.KindEndorse, title.KindBlank:
+		if len(args) != 3 {
+			return msg.Error("cli.err.args-named", "transfer", 3, "BF_TX id, "+args[1]+", endorsee public key")
+		}
+		req.To = args[2]
+	case title.KindSurrender:
+		if len(args) != 2 {
+			return msg.Error("cli.err.args-named", "transfer", 2, "BF_TX id, surrender")
+		}
+	default:
+		return msg.Error("cli.err.unknown-transfer", args[1])
+	}
+	if c.String("key") == "" {
+		return msg.Error("cli.err.command-needs-key", "transfer")
+	}
+
+	// Load the key of the holder from the keystore
+	privatekey, err := loadKey(c, c.String("key"))
+	if err != nil {
+		return err
+	}
+
+	// Wrap the transfer in an envelope signed by the holder
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	env := envelope.New(envelope.TxTransfer, uint64(time.Now().UnixNano()), payload)
+	if err := env.Sign(privatekey); err != nil {
+		return err
+	}
+
+	// The application checks the sender holds the title
+	res := client.DeliverTxSync(env.Encode())
+	if res.IsErr() {
+		printResponse(c, response{
+			Code: res.Code,
+			Log:  res.Log,
+		})
+		return nil
+	}
+	res = client.CommitSync()
+
+	//Result
+	printResponse(c, response{
+		Code:   res.Code,
+		Data:   res.Data,
+		Log:    res.Log,
+		Result: msg.T("cli.msg.transfer", req.Kind, req.Id),
+	})
+	return nil
+}
+
+// Get the holder and the endorsements of a BF_TX from the application
+func cmdHolderBfTx(c *cli.Context) error {
 	args := c.Args()
 	if len(args) != 1 {
-		return errors.New("Command print takes 1 argument")
+		return msg.Error("cli.err.args", "holder", 1)
 	}
 
-	// Get a BF_TX by id
-	bftx, err := leveldb.GetBfTx(args[0])
+	resQuery, err := client.QuerySync(types.RequestQuery{Path: bft.PathTitle + args[0]})
+	if err != nil {
+		return err
+	}
+	if !resQuery.Code.IsOK() {
+		printResponse(c, response{
+			Code: resQuery.Code,
+			Log:  resQuery.Log,
+		})
+		return nil
+	}
+	if resQuery.Value == nil {
+		return msg.Error("cli.err.title-missing", args[0])
+	}
+	record, err := title.DecodeRecord(resQuery.Value)
 	if err != nil {
 		return err
 	}
 
-	// Print the BF_TX clearly
-	bf_tx.PrintBFTX(bftx)
+	// Result
+	result := msg.T("cli.msg.holder", record.Holder)
+	if record.Surrendered {
+		result = msg.T("cli.msg.holder-surrendered", record.Holder)
+	} else if record.Bearer {
+		result = msg.T("cli.msg.holder-bearer", record.Holder)
+	}
+	printResponse(c, response{
+		Result: result,
+	})
+	for _, endorsement := range record.Endorsements {
+		fmt.Println(msg.T("cli.msg.endorsement", endorsement.Height, endorsement.Kind, endorsement.From, endorsement.To))
+	}
 	return nil
 }
 
+// stateNames returns the states of the lifecycle, separated by commas.
+func stateNames() string {
+	var names []string
+	for _, state := range lifecycle.States() {
+		names = append(names, string(state))
+	}
+	return strings.Join(names, ", ")
+}
+
 func cmdTotalBfTx(c *cli.Context) error {
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
 	// Query the total of BF_TX in DB
-	total, err := leveldb.Total()
+	total, err := storage.Total(db)
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.total", total),
+	})
+	return nil
+}
+
+// Search the BF_TX by the indexed fields
+func cmdSearchBfTx(c *cli.Context) error {
+	query := storage.Query{
+		Offset: c.Int("offset"),
+		Limit:  c.Int("limit"),
+	}
+	for _, arg := range c.Args() {
+		condition, err := storage.ParseCondition(arg)
+		if err != nil {
+			return err
+		}
+		query.Conditions = append(query.Conditions, condition)
+	}
+	if format := c.String("format"); format != "text" && format != "json" {
+		return msg.Error("cli.err.unknown-format", format)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := storage.Search(db, query)
+	if err == storage.ErrIndexMissing {
+		return msg.Error("cli.err.index-missing")
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.String("format") == "json" {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	for _, id := range result.Ids {
+		fmt.Println(id)
+	}
+	printResponse(c, response{
+		Result: msg.T("cli.msg.search", len(result.Ids), result.Total),
+	})
+	return nil
+}
+
+// Index again every BF_TX, for DBs written before the indexes existed
+func cmdRebuildIndex(c *cli.Context) error {
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	total, err := storage.RebuildIndex(db)
 	if err != nil {
 		return err
 	}
 
 	// Result
 	printResponse(c, response{
-		Result: "Total BF_TX on BD: " + strconv.Itoa(total),
+		Result: msg.T("cli.msg.index-rebuilt", total),
 	})
 	return nil
 }
@@ -868,35 +1629,28 @@ func stringOrHexToBytes(s string) ([]byte, error) {
 	if len(s) > 2 && strings.ToLower(s[:2]) == "0x" {
 		b, err := hex.DecodeString(s[2:])
 		if err != nil {
-			err = fmt.Errorf("Error decoding hex argument: %s", err.Error())
+			err = msg.Error("cli.err.hex-argument", err.Error())
 			return nil, err
 		}
 		return b, nil
 	}
 
 	if !strings.HasPrefix(s, "\"") || !strings.HasSuffix(s, "\"") {
-		err := fmt.Errorf("Invalid string arg: \"%s\". Must be quoted or a \"0x\"-prefixed hex string", s)
+		err := msg.Error("cli.err.string-argument", s)
 		return nil, err
 	}
 
 	return []byte(s[1 : len(s)-1]), nil
 }
 
+// introduction prints the banner to stderr, so stdout only carries the output of the command.
 func introduction(c *cli.Context) {
-	fmt.Println("\n...........................................")
-	fmt.Println("Blockfreight™ Go App")
-	fmt.Println("Address " + c.GlobalString("address"))
-	fmt.Println("BFT Implementation:  " + c.GlobalString("call"))
-	fmt.Println("...........................................\n")
-	/*name := "Blockfreight Community"
-	  if c.NArg() > 0 {
-	    name = c.Args().Get(0)
-	  }
-	  if c.String("lang") == "ES" { //ISO 639-1
-	    fmt.Println("Hola", name)
-	  } else {
-	    fmt.Println("Hello", name)
-	  }*/
+	fmt.Fprintln(os.Stderr, "\n...........................................")
+	fmt.Fprintln(os.Stderr, msg.T("cli.banner.title"))
+	fmt.Fprintln(os.Stderr, msg.T("cli.banner.address", c.GlobalString("address")))
+	fmt.Fprintln(os.Stderr, msg.T("cli.banner.call", c.GlobalString("call")))
+	fmt.Fprintln(os.Stderr, "...........................................")
+	fmt.Fprintln(os.Stderr, "")
 }
 
 // =================================================
@@ -0,0 +1,264 @@
+// File: ./blockfreight/cmd/bftx/db.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package main
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"fmt"     // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"io"      // Provides basic interfaces to I/O primitives.
+	"os"      // Provides a platform-independent interface to operating system functionality.
+	"sort"    // Provides primitives for sorting slices and user-defined collections.
+	"strings" // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"github.com/urfave/cli" // Provides structure and function to build command line apps in Go.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/pkg/storage" // Defines the BFTXStore interface of the embedded stores.
+)
+
+// Export the DB to an archive file, or to the standard output
+func cmdDbExport(c *cli.Context) error {
+	args := c.Args()
+	if len(args) > 1 {
+		return msg.Error("cli.err.args", "db export", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	path := args.First()
+	if path == "" || path == "-" {
+		_, err := storage.Export(db, os.Stdout)
+		return err
+	}
+
+	// Write a temporary file first, so a failed export does not leave a truncated archive behind
+	f, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	trailer, err := storage.Export(db, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		os.Remove(path + ".tmp")
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.exported", trailer.Entries, trailer.Records, path),
+	})
+	return nil
+}
+
+// Import an archive file, or the standard input, into the DB
+func cmdDbImport(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "db import", 1)
+	}
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := storage.Import(db, r, c.String("on-conflict"))
+	if err == storage.ErrConflict {
+		return msg.Error("cli.err.conflict", len(result.Conflicts), strings.Join(result.Conflicts, ", "))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.imported", result.Imported, result.Unchanged, result.Skipped),
+	})
+	return nil
+}
+
+// Copy a consistent snapshot of the DB, taken while it is in use, to a new DB
+func cmdDbSnapshot(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "db snapshot", 1)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := storage.Open(cfg.Database.Backend, args[0], nil)
+	if err != nil {
+		return err
+	}
+	n, err := storage.Copy(db, snapshot)
+	if closeErr := snapshot.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.snapshot", n, args[0]),
+	})
+	return nil
+}
+
+// Migrate the records of the DB to the current storage format version
+func cmdDbMigrate(c *cli.Context) error {
+	if len(c.Args()) != 0 {
+		return msg.Error("cli.err.args", "db migrate", 0)
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	dryRun := c.Bool("dry-run")
+	result, err := storage.Migrate(db, dryRun)
+	for _, failure := range result.Failures {
+		fmt.Println(msg.T("cli.msg.migration-failure", failure.Id, failure.Error))
+	}
+	if err == storage.ErrMigrationFailed {
+		return msg.Error("cli.err.migration-failed", len(result.Failures))
+	}
+	if err != nil {
+		return err
+	}
+
+	// The plan, by record version
+	if dryRun {
+		var versions []int
+		for version := range result.ByVersion {
+			versions = append(versions, version)
+		}
+		sort.Ints(versions)
+		for _, version := range versions {
+			fmt.Println(msg.T("cli.msg.migration-plan", result.ByVersion[version], version, storage.RecordVersion))
+		}
+		for _, migration := range storage.Migrations() {
+			if len(versions) > 0 && migration.From >= versions[0] {
+				fmt.Printf("  %d -> %d: %s\n", migration.From, migration.From+1, migration.Description)
+			}
+		}
+	}
+
+	if result.Reindexed {
+		fmt.Println(msg.T("cli.msg.index-rebuilt", result.Total))
+	}
+
+	// Result
+	key := "cli.msg.migrated"
+	if dryRun {
+		key = "cli.msg.migrate-dry-run"
+	}
+	printResponse(c, response{
+		Result: msg.T(key, result.Migrated, storage.RecordVersion, result.Current),
+	})
+	return nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,247 @@
+// File: ./blockfreight/cmd/bftx/keys.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package main
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bufio"        // Implements buffered I/O.
+	"crypto/ecdsa" // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
+	"fmt"          // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"io/ioutil"    // Implements some I/O utility functions.
+	"os"           // Provides a platform-independent interface to operating system functionality.
+	"strings"      // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"github.com/urfave/cli"            // Provides structure and function to build command line apps in Go.
+	"golang.org/x/crypto/ssh/terminal" // Provides support functions for dealing with terminals.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto" // Provides useful functions to sign BF_TX.
+)
+
+// passphraseEnv is the environment variable which can hold the keystore passphrase for non interactive use.
+const passphraseEnv = "BFTX_PASSPHRASE"
+
+// Open the keystore defined by the global flags
+func openKeystore(c *cli.Context) (*crypto.Keystore, error) {
+	return crypto.NewKeystore(c.GlobalString("keystore"))
+}
+
+// Read the passphrase from the environment, the terminal or the standard input
+func readPassphrase(name string) (string, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fmt.Print(msg.T("cli.msg.passphrase", name))
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println("")
+		return string(passphrase), err
+	}
+	line, _, err := bufio.NewReader(os.Stdin).ReadLine()
+	return string(line), err
+}
+
+// Load a private key from the keystore, asking for its passphrase
+func loadKey(c *cli.Context, name string) (*ecdsa.PrivateKey, error) {
+	ks, err := openKeystore(c)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := readPassphrase(name)
+	if err != nil {
+		return nil, err
+	}
+	return ks.Load(name, passphrase)
+}
+
+// Create a new key in the keystore
+func cmdKeysCreate(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "keys create", 1)
+	}
+
+	ks, err := openKeystore(c)
+	if err != nil {
+		return err
+	}
+	passphrase, err := readPassphrase(args[0])
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return msg.Error("cli.err.empty-passphrase")
+	}
+
+	pubkey, err := ks.Create(args[0], passphrase)
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.key-created", args[0], crypto.EncodePublicKey(pubkey)),
+	})
+	return nil
+}
+
+// Import an existing private key (hex file) into the keystore
+func cmdKeysImport(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 2 {
+		return msg.Error("cli.err.args-named", "keys import", 2, "name, private key filepath")
+	}
+
+	hexkey, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return msg.Error("cli.err.file", err.Error())
+	}
+
+	ks, err := openKeystore(c)
+	if err != nil {
+		return err
+	}
+	passphrase, err := readPassphrase(args[0])
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		return msg.Error("cli.err.empty-passphrase")
+	}
+
+	pubkey, err := ks.ImportHex(args[0], string(hexkey), passphrase)
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.key-imported", args[0], crypto.EncodePublicKey(pubkey)),
+	})
+	return nil
+}
+
+// List the keys of the keystore
+func cmdKeysList(c *cli.Context) error {
+	ks, err := openKeystore(c)
+	if err != nil {
+		return err
+	}
+	keys, err := ks.List()
+	if err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key.Name+" "+key.PublicKey)
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.keys", len(keys)) + "\n" + strings.Join(lines, "\n"),
+	})
+	return nil
+}
+
+// Delete a key from the keystore
+func cmdKeysDelete(c *cli.Context) error {
+	args := c.Args()
+	if len(args) != 1 {
+		return msg.Error("cli.err.args", "keys delete", 1)
+	}
+
+	ks, err := openKeystore(c)
+	if err != nil {
+		return err
+	}
+	err = ks.Delete(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Result
+	printResponse(c, response{
+		Result: msg.T("cli.msg.key-deleted", args[0]),
+	})
+	return nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
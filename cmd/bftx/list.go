@@ -0,0 +1,231 @@
+// File: ./blockfreight/cmd/bftx/list.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package main
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/csv"   // Reads and writes comma-separated values (CSV) files.
+	"encoding/json"  // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"fmt"            // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"os"             // Provides a platform-independent interface to operating system functionality.
+	"text/tabwriter" // Implements a write filter that translates tabbed columns in input into properly aligned text.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"github.com/urfave/cli" // Provides structure and function to build command line apps in Go.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/pkg/storage" // Defines the BFTXStore interface of the embedded stores.
+)
+
+// summaryWriter writes the summaries of bftx list in one of the output formats.
+type summaryWriter interface {
+	Write(summary storage.Summary) error
+	// Close ends the output, given the cursor of the next page.
+	Close(next string) error
+}
+
+// List the BF_TX stored in the DB
+func cmdListBfTx(c *cli.Context) error {
+	if len(c.Args()) != 0 {
+		return msg.Error("cli.err.args", "list", 0)
+	}
+
+	var w summaryWriter
+	switch c.String("format") {
+	case "table":
+		w = newTableWriter(c)
+	case "json":
+		w = &jsonWriter{}
+	case "csv":
+		w = newCSVWriter()
+	default:
+		return msg.Error("cli.err.unknown-list-format", c.String("format"))
+	}
+
+	// Open the DB
+	db, err := openStore(c)
+	if err != nil {
+		return err
+	}
+
+	next, err := storage.List(db, storage.ListOptions{
+		State:  c.String("state"),
+		Sort:   c.String("sort"),
+		Offset: c.Int("offset"),
+		Limit:  c.Int("limit"),
+		Cursor: c.String("cursor"),
+	}, w.Write)
+	if err == storage.ErrInvalidCursor {
+		return msg.Error("cli.err.invalid-cursor")
+	}
+	if err != nil {
+		return err
+	}
+	return w.Close(next)
+}
+
+// tableWriter aligns the summaries in columns, and prints the cursor of the next page after them.
+type tableWriter struct {
+	c  *cli.Context
+	tw *tabwriter.Writer
+}
+
+func newTableWriter(c *cli.Context) *tableWriter {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, msg.T("cli.msg.list-header"))
+	return &tableWriter{c, tw}
+}
+
+func (w *tableWriter) Write(summary storage.Summary) error {
+	_, err := fmt.Fprintf(w.tw, "%s\t%s\t%s\t%s\t%s\t%s\n", summary.Id, summary.BolNum, summary.State,
+		summary.Shipper, summary.DateShipped, summary.Amendment)
+	return err
+}
+
+func (w *tableWriter) Close(next string) error {
+	if err := w.tw.Flush(); err != nil {
+		return err
+	}
+	if next != "" {
+		printResponse(w.c, response{
+			Result: msg.T("cli.msg.next-page", next),
+		})
+	}
+	return nil
+}
+
+// jsonWriter streams a JSON object holding the summaries and the cursor of the next page.
+type jsonWriter struct {
+	n int
+}
+
+func (w *jsonWriter) Write(summary storage.Summary) error {
+	out, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if w.n == 0 {
+		fmt.Print("{\"bftx\":[\n  ")
+	} else {
+		fmt.Print(",\n  ")
+	}
+	w.n++
+	fmt.Print(string(out))
+	return nil
+}
+
+func (w *jsonWriter) Close(next string) error {
+	if w.n == 0 {
+		fmt.Print("{\"bftx\":[")
+	} else {
+		fmt.Print("\n")
+	}
+	out, err := json.Marshal(next)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("],\"next\":%s}\n", out)
+	return nil
+}
+
+// csvWriter writes the summaries as CSV with a header row. The cursor of the next page goes to stderr, so the
+// output stays a valid CSV file.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter() *csvWriter {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"id", "bol_num", "state", "shipper", "date_shipped", "amendment"})
+	return &csvWriter{w}
+}
+
+func (w *csvWriter) Write(summary storage.Summary) error {
+	return w.w.Write([]string{summary.Id, summary.BolNum, summary.State, summary.Shipper, summary.DateShipped,
+		summary.Amendment})
+}
+
+func (w *csvWriter) Close(next string) error {
+	w.w.Flush()
+	if next != "" {
+		fmt.Fprintln(os.Stderr, msg.T("cli.msg.next-page", next))
+	}
+	return w.w.Error()
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -46,7 +46,47 @@
 
 package config
 
-// @todo: Specify application variables here.
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"io/ioutil"     // Implements some I/O utility functions.
+	"os"            // Provides a platform-independent interface to operating system functionality.
+)
+
+// DefaultPath is the configuration file read by bftx when --config is not given.
+const DefaultPath = "bftx.json"
+
+// Config struct
+type Config struct {
+	Lang     string   `json:"lang"`   // Locale of the messages, e.g. "es".
+	Schema   string   `json:"schema"` // JSON Schema used to validate the BF_TX documents.
+	Database Database `json:"database"`
+}
+
+// Database struct
+type Database struct {
+	Backend       string `json:"backend"`         // Name of the storage backend, "leveldb" by default.
+	Path          string `json:"path"`            // Folder of the DB.
+	Sync          bool   `json:"sync"`            // Flush every write to disk before returning.
+	CacheSize     int    `json:"cache_size"`      // Capacity of the block cache in MiB.
+	LockTimeoutMs int    `json:"lock_timeout_ms"` // How long to wait for a DB locked by another process.
+}
+
+// Load reads the JSON configuration file stored in path. A missing file gives the default configuration.
+func Load(path string) (Config, error) {
+	var config Config
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
 
 // =================================================
 // Blockfreight™ | The blockchain of global freight.
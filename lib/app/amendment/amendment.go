@@ -0,0 +1,218 @@
+// File: ./blockfreight/lib/app/amendment/amendment.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package amendment defines the amendment chain of a Bill of Lading: the revisions which replace a BF_TX, the changes
+// each one makes and the approvals of the parties it affects.
+package amendment
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"         // Implements functions for the manipulation of byte slices.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"reflect"       // Implements run-time reflection, allowing a program to manipulate objects with arbitrary types.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+var (
+	// ErrNotRequired is returned when a party which is not affected by the amendment approves it.
+	ErrNotRequired = errors.New("The party is not required to approve the amendment.")
+	// ErrApproved is returned when a party approves the same amendment twice.
+	ErrApproved = errors.New("The party already approved the amendment.")
+	// ErrNoChanges is returned when an amendment leaves the Bill of Lading as it is.
+	ErrNoChanges = errors.New("The amendment does not change the Bill of Lading.")
+)
+
+// Request is the payload of an amendment transaction. It proposes the amended BF_TX of its parent, or approves
+// the pending amendment Id when BFTX is empty.
+type Request struct {
+	Parent string          `json:"parent"`
+	Id     string          `json:"id,omitempty"`
+	BFTX   json.RawMessage `json:"bftx,omitempty"`
+}
+
+// Revision is a BF_TX in its amendment chain. The parties are named by their hex encoded public key.
+type Revision struct {
+	Id        string   `json:"id"`
+	Parent    string   `json:"parent,omitempty"` // BF_TX amended by this one, empty for the original.
+	Next      string   `json:"next,omitempty"`   // BF_TX which amends this one.
+	Proposer  string   `json:"proposer"`
+	Height    uint64   `json:"height"`
+	Changes   []string `json:"changes"`  // Properties changed from the parent.
+	Required  []string `json:"required"` // Parties which approve the revision.
+	Approvals []string `json:"approvals"`
+}
+
+// Proposal is a pending amendment with the BF_TX it proposes.
+type Proposal struct {
+	Revision
+	BFTX json.RawMessage `json:"bftx"`
+}
+
+// NewRevision returns the revision of an original BF_TX, created by a party at a height.
+func NewRevision(id, issuer string, height uint64) Revision {
+	return Revision{Id: id, Proposer: issuer, Height: height, Changes: []string{}, Required: []string{issuer}, Approvals: []string{issuer}}
+}
+
+// Approve records the approval of a required party.
+func (revision *Revision) Approve(party string) error {
+	if contains(revision.Approvals, party) {
+		return ErrApproved
+	}
+	if !contains(revision.Required, party) {
+		return ErrNotRequired
+	}
+	revision.Approvals = append(revision.Approvals, party)
+	return nil
+}
+
+// Require replaces the parties which approve the revision, and drops the approvals of the parties no longer required.
+func (revision *Revision) Require(parties []string) {
+	approvals := []string{}
+	for _, party := range revision.Approvals {
+		if contains(parties, party) {
+			approvals = append(approvals, party)
+		}
+	}
+	revision.Required = parties
+	revision.Approvals = approvals
+}
+
+// Missing returns the required parties which have not approved the revision yet.
+func (revision Revision) Missing() []string {
+	missing := []string{}
+	for _, party := range revision.Required {
+		if !contains(revision.Approvals, party) {
+			missing = append(missing, party)
+		}
+	}
+	return missing
+}
+
+// Changes returns the names of the Bill of Lading properties which differ between two BF_TX.
+func Changes(old, amended bf_tx.BF_TX) []string {
+	changes := []string{}
+	oldValue, newValue := reflect.ValueOf(old.Properties), reflect.ValueOf(amended.Properties)
+	for i := 0; i < oldValue.NumField(); i++ {
+		a, _ := json.Marshal(oldValue.Field(i).Interface())
+		b, _ := json.Marshal(newValue.Field(i).Interface())
+		if !bytes.Equal(a, b) {
+			changes = append(changes, oldValue.Type().Field(i).Name)
+		}
+	}
+	if old.Type != amended.Type {
+		changes = append(changes, "Type")
+	}
+	return changes
+}
+
+// Encode returns the JSON encoding of the revision.
+func (revision Revision) Encode() []byte {
+	buf, _ := json.Marshal(revision)
+	return buf
+}
+
+// DecodeRevision parses the JSON encoding of a revision.
+func DecodeRevision(buf []byte) (Revision, error) {
+	var revision Revision
+	err := json.Unmarshal(buf, &revision)
+	return revision, err
+}
+
+// Encode returns the JSON encoding of the proposal.
+func (proposal Proposal) Encode() []byte {
+	buf, _ := json.Marshal(proposal)
+	return buf
+}
+
+// DecodeProposal parses the JSON encoding of a proposal.
+func DecodeProposal(buf []byte) (Proposal, error) {
+	var proposal Proposal
+	err := json.Unmarshal(buf, &proposal)
+	return proposal, err
+}
+
+func contains(parties []string, party string) bool {
+	for _, p := range parties {
+		if p == party {
+			return true
+		}
+	}
+	return false
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
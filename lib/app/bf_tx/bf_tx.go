@@ -50,10 +50,10 @@ import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"crypto/ecdsa"  // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
-	"crypto/sha256" // Implements the SHA256 Algorithm for Hash.
-	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
-	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"crypto/sha256"   // Implements the SHA256 Algorithm for Hash.
+	"encoding/binary" // Implements translation between numbers and byte sequences.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"encoding/json"   // Implements encoding and decoding of JSON as defined in RFC 4627.
 
 	// ====================
 	// Third-party packages
@@ -67,24 +67,46 @@ import (
 )
 
 // SetBFTX receives the path of a JSON, reads it and returns the BF_TX structure with all attributes.
+// JSON files in the legacy placeholder format are converted.
 func SetBFTX(jsonpath string) (BF_TX, error) {
 	var bftx BF_TX
 	file, err := common.ReadJSON(jsonpath)
 	if err != nil {
 		return bftx, err
 	}
-	json.Unmarshal(file, &bftx)
-	return bftx, nil
+	if IsLegacy(file) {
+		return ConvertLegacy(file)
+	}
+	err = json.Unmarshal(file, &bftx)
+	return bftx, err
 }
 
-//HashBFTX hashes the BF_TX object
-func HashBFTX(bftx BF_TX) ([]byte, error) {
-	bftxBytes := []byte(fmt.Sprintf("%v", bftx))
+// DecodeBFTX receives the JSON content of a BF_TX and returns the BF_TX structure.
+func DecodeBFTX(data []byte) (BF_TX, error) {
+	var bftx BF_TX
+	err := json.Unmarshal(data, &bftx)
+	return bftx, err
+}
 
-	hash := sha256.New()
-	hash.Write(bftxBytes)
+// CanonicalContent returns the canonical encoding (RFC 8785) of the Bill of Lading content of the BF_TX.
+// Only Type and Properties are covered, so bookkeeping attributes never change the result.
+// It is the input of the BF_TX id, of its signature and of the content verification.
+func CanonicalContent(bftx BF_TX) ([]byte, error) {
+	return common.CanonicalJSON(struct {
+		Type       string
+		Properties Properties
+	}{bftx.Type, bftx.Properties})
+}
 
-	return hash.Sum(nil), nil
+//HashBFTX hashes the canonical content of the BF_TX object
+func HashBFTX(bftx BF_TX) ([]byte, error) {
+	content, err := CanonicalContent(bftx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(content)
+	return hash[:], nil
 }
 
 //GenerateBFTXSalt hashes two byte arrays and returns it.
@@ -92,6 +114,21 @@ func GenerateBFTXSalt(hash []byte, salt []byte) []byte {
 	return common.HashByteArrays(hash, salt)
 }
 
+// GenerateBFTXID returns the id of a new BF_TX: its content hash salted with the chain state and a nonce, in hex.
+// The nonce 0 gives the same id as GenerateBFTXSalt, the next ones resolve the collisions of identical contents.
+func GenerateBFTXID(bftx BF_TX, salt []byte, nonce uint64) (string, error) {
+	hash, err := HashBFTX(bftx)
+	if err != nil {
+		return "", err
+	}
+	if nonce > 0 {
+		n := make([]byte, 8)
+		binary.BigEndian.PutUint64(n, nonce)
+		salt = append(append([]byte{}, salt...), n...)
+	}
+	return hex.EncodeToString(GenerateBFTXSalt(hash, salt)), nil
+}
+
 // BFTXContent receives the BF_TX structure, applies it the json.Marshal procedure and return the content of the BF_TX JSON.
 func BFTXContent(bftx BF_TX) (string, error) {
 	jsonContent, err := json.Marshal(bftx)
@@ -103,7 +140,8 @@ func PrintBFTX(bftx BF_TX) {
 	spew.Dump(bftx)
 }
 
-// State reports the current state of a BF_TX
+// State reports the local state of a BF_TX, from its flags. The application enforces the lifecycle of the
+// broadcast BF_TX, see package lifecycle.
 func State(bftx BF_TX) string {
 	if bftx.Transmitted {
 		return "Transmitted!"
@@ -116,12 +154,10 @@ func State(bftx BF_TX) string {
 
 // Reinitialize set the default values to the Blockfreight attributes of BF_TX
 func Reinitialize(bftx BF_TX) BF_TX {
-	bftx.PrivateKey.Curve = nil
-	bftx.PrivateKey.X = nil
-	bftx.PrivateKey.Y = nil
-	bftx.PrivateKey.D = nil
+	bftx.PublicKey = ""
 	bftx.Signhash = nil
 	bftx.Signature = ""
+	bftx.SignAlgorithm = ""
 	bftx.Verified = false
 	bftx.Transmitted = false
 	return bftx
@@ -138,189 +174,119 @@ type BF_TX struct {
 	// ===================================
 	// Blockfreight Transaction attributes
 	// ===================================
-	Id          string
-	PrivateKey  ecdsa.PrivateKey
-	Signhash    []uint8
-	Signature   string
-	Verified    bool
-	Transmitted bool
-	Amendment   string
+	Id            string
+	PublicKey     string // Hex encoded uncompressed P-256 public key of the signer.
+	Signhash      []uint8
+	Signature     string
+	SignAlgorithm string // Algorithm identifier of the signature, empty for legacy MD5 signatures.
+	Verified      bool
+	Transmitted   bool
+	Amendment     string
 }
 
-// Properties struct
+// Properties struct holds the content of the Bill of Lading.
 type Properties struct {
-	Shipper             Shipper
-	BolNum              BolNum
-	RefNum              RefNum
-	Consignee           Consignee
-	Vessel              Vessel
-	PortOfLoading       PortLoading
-	PortOfDischarge     PortDischarge
-	NotifyAddress       NotifyAddress
-	DescOfGoods         DescGoods
-	GrossWeight         GrossWeight
-	FreightPayableAmt   FreightPayableAmt
-	FreightAdvAmt       FreightAdvAmt
-	GeneralInstructions GeneralInstructions
-	DateShipped         Date
-	IssueDetails        IssueDetails
-	NumBol              NumBol
-	MasterInfo          MasterInfo
-	AgentForMaster      AgentMaster
-	AgentForOwner       AgentOwner
-}
-
-// Shipper struct
-type Shipper struct {
-	Type string
-}
-
-// BolNum struct
-type BolNum struct {
-	Type int
-}
-
-// RefNum struct
-type RefNum struct {
-	Type int
-}
-
-// Consignee struct
-type Consignee struct {
-	Type string //Null
+	BolNum                string
+	RefNum                string
+	Shipper               Party
+	Consignee             Party // Empty for bills made out "to order".
+	NotifyParty           Party
+	Vessel                Vessel
+	PortOfLoading         Port
+	PortOfDischarge       Port
+	Cargo                 []CargoItem
+	Freight               Freight
+	GeneralInstructions   string
+	DateShipped           string // ISO 8601 date (YYYY-MM-DD).
+	IssueDetails          IssueDetails
+	NumBol                int // Number of original bills issued.
+	MasterInfo            Signatory
+	AgentForMaster        Signatory
+	AgentForOwner         Signatory
+	ConditionsForCarriage string
+}
+
+// Party struct represents a company or person named in the Bill of Lading.
+type Party struct {
+	Name     string
+	Address  Address
+	TaxId    string
+	Contacts []Contact
+}
+
+// Address struct
+type Address struct {
+	Lines      []string
+	City       string
+	PostalCode string
+	Region     string
+	Country    string // ISO 3166-1 alpha-2 code.
+}
+
+// Contact struct
+type Contact struct {
+	Name  string
+	Phone string
+	Email string
 }
 
 // Vessel struct
 type Vessel struct {
-	Type int
-}
-
-// PortLoading struct
-type PortLoading struct {
-	Type int
-}
-
-// PortDischarge struct
-type PortDischarge struct {
-	Type int
-}
-
-// NotifyAddress struct
-type NotifyAddress struct {
-	Type string
-}
-
-// DescGoods struct
-type DescGoods struct {
-	Type string
+	Name      string
+	IMONumber string // 7 digits IMO ship identification number.
+	Voyage    string
 }
 
-// GrossWeight struct
-type GrossWeight struct {
-	Type int
+// Port struct
+type Port struct {
+	Locode string // UN/LOCODE, e.g. AUMEL.
+	Name   string
 }
 
-// FreightPayableAmt struct
-type FreightPayableAmt struct {
-	Type int
+// CargoItem struct represents a line of the description of goods.
+type CargoItem struct {
+	Marks       string
+	Packages    int
+	PackageType string
+	Description string
+	HSCode      string // Harmonized System code, 6 to 10 digits.
+	GrossWeight Measure
+	Volume      Measure
 }
 
-// FreightAdvAmt struct
-type FreightAdvAmt struct {
-	Type int
+// Measure struct holds a quantity with its UN/ECE Recommendation 20 unit code (KGM, TNE, LBR, MTQ, FTQ).
+type Measure struct {
+	Value float64
+	Unit  string
 }
 
-// GeneralInstructions struct
-type GeneralInstructions struct {
-	Type string
+// Freight struct holds the freight terms and charges.
+type Freight struct {
+	Terms         string // PREPAID or COLLECT.
+	Currency      string // ISO 4217 code.
+	Charges       []Charge
+	PayableAmount float64
+	AdvanceAmount float64
 }
 
-// Date struct
-type Date struct {
-	Type   int
-	Format string
+// Charge struct
+type Charge struct {
+	Description string
+	Amount      float64
+	Terms       string // PREPAID or COLLECT.
 }
 
 // IssueDetails struct
 type IssueDetails struct {
-	Type       string
-	Properties IssueDetailsProperties
-}
-
-// IssueDetailsProperties struct
-type IssueDetailsProperties struct {
-	PlaceOfIssue PlaceIssue
-	DateOfIssue  Date
-}
-
-// PlaceIssue struct
-type PlaceIssue struct {
-	Type string
-}
-
-// NumBol struct
-type NumBol struct {
-	Type int
-}
-
-// MasterInfo struct
-type MasterInfo struct {
-	Type       string
-	Properties MasterInfoProperties
-}
-
-// MasterInfoProperties struct
-type MasterInfoProperties struct {
-	FirstName FirstName
-	LastName  LastName
-	Sig       Sig
-}
-
-// AgentMaster struct
-type AgentMaster struct {
-	Type       string
-	Properties AgentMasterProperties
-}
-
-//AgentMasterProperties struct
-type AgentMasterProperties struct {
-	FirstName FirstName
-	LastName  LastName
-	Sig       Sig
-}
-
-// AgentOwner struct
-type AgentOwner struct {
-	Type       string
-	Properties AgentOwnerProperties
-}
-
-// AgentOwnerProperties struct
-type AgentOwnerProperties struct {
-	FirstName             FirstName
-	LastName              LastName
-	Sig                   Sig
-	ConditionsForCarriage ConditionsCarriage
-}
-
-// FirstName struct
-type FirstName struct {
-	Type string
-}
-
-// LastName struct
-type LastName struct {
-	Type string
-}
-
-// Sig struct
-type Sig struct {
-	Type string
+	PlaceOfIssue string
+	DateOfIssue  string // ISO 8601 date (YYYY-MM-DD).
 }
 
-// ConditionsCarriage struct
-type ConditionsCarriage struct {
-	Type string
+// Signatory struct represents a person who signs the Bill of Lading.
+type Signatory struct {
+	FirstName string
+	LastName  string
+	Sig       string
 }
 
 // =================================================
@@ -0,0 +1,271 @@
+// File: ./blockfreight/lib/bf_tx/legacy.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bf_tx
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"           // Implements functions for the manipulation of byte slices.
+	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"encoding/json"   // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"math/big"        // Implements arbitrary-precision arithmetic (big numbers).
+	"strconv"         // Implements conversions to and from string representations of basic data types.
+	"time"            // Provides functionality for measuring and displaying time.
+)
+
+// legacyBFTX mirrors the first BF_TX format, where every attribute was a {"type": value} placeholder of the JSON schema draft.
+type legacyBFTX struct {
+	Type       string
+	Properties struct {
+		Shipper             legacyString
+		BolNum              legacyInt
+		RefNum              legacyInt
+		Consignee           legacyString
+		Vessel              legacyInt
+		PortOfLoading       legacyInt
+		PortOfDischarge     legacyInt
+		NotifyAddress       legacyString
+		DescOfGoods         legacyString
+		GrossWeight         legacyInt
+		FreightPayableAmt   legacyInt
+		FreightAdvAmt       legacyInt
+		GeneralInstructions legacyString
+		DateShipped         legacyInt
+		IssueDetails        struct {
+			Properties struct {
+				PlaceOfIssue legacyString
+				DateOfIssue  legacyInt
+			}
+		}
+		NumBol         legacyInt
+		MasterInfo     legacySignatory
+		AgentForMaster legacySignatory
+		AgentForOwner  struct {
+			Properties struct {
+				FirstName             legacyString
+				LastName              legacyString
+				Sig                   legacyString
+				ConditionsForCarriage legacyString
+			}
+		}
+	}
+
+	Id          string
+	PrivateKey  legacyKey // The first releases stored the signing key; only its public key is kept.
+	PublicKey   string
+	Signhash    []uint8
+	Signature   string
+	Verified    bool
+	Transmitted bool
+	Amendment   string
+}
+
+// legacyKey mirrors the ecdsa.PrivateKey of the first releases, which embeds the public key X and Y.
+type legacyKey struct {
+	X, Y, D *big.Int
+}
+
+type legacyString struct {
+	Type string
+}
+
+type legacyInt struct {
+	Type int
+}
+
+type legacySignatory struct {
+	Properties struct {
+		FirstName legacyString
+		LastName  legacyString
+		Sig       legacyString
+	}
+}
+
+// IsLegacy reports whether the JSON content of a BF_TX uses the legacy placeholder format.
+func IsLegacy(data []byte) bool {
+	var probe struct {
+		Properties struct {
+			BolNum json.RawMessage
+		}
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(probe.Properties.BolNum), []byte("{"))
+}
+
+// ConvertLegacy receives the JSON content of a BF_TX in the legacy placeholder format and returns it in the current format.
+// The public key is derived from the stored private key, which is dropped. The legacy signature is kept but the BF_TX
+// is not verified: its signature has to be checked with crypto.VerifyLegacy on the legacy content, or renewed.
+func ConvertLegacy(data []byte) (BF_TX, error) {
+	var legacy legacyBFTX
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return BF_TX{}, err
+	}
+	old := legacy.Properties
+
+	bftx := BF_TX{
+		Type: legacy.Type,
+		Properties: Properties{
+			BolNum:          legacyNumber(old.BolNum),
+			RefNum:          legacyNumber(old.RefNum),
+			Shipper:         Party{Name: old.Shipper.Type},
+			Consignee:       Party{Name: old.Consignee.Type},
+			Vessel:          Vessel{IMONumber: legacyNumber(old.Vessel)},
+			PortOfLoading:   Port{Name: legacyNumber(old.PortOfLoading)},
+			PortOfDischarge: Port{Name: legacyNumber(old.PortOfDischarge)},
+			Freight: Freight{
+				PayableAmount: float64(old.FreightPayableAmt.Type),
+				AdvanceAmount: float64(old.FreightAdvAmt.Type),
+			},
+			GeneralInstructions: old.GeneralInstructions.Type,
+			DateShipped:         legacyDate(old.DateShipped),
+			IssueDetails: IssueDetails{
+				PlaceOfIssue: old.IssueDetails.Properties.PlaceOfIssue.Type,
+				DateOfIssue:  legacyDate(old.IssueDetails.Properties.DateOfIssue),
+			},
+			NumBol:         old.NumBol.Type,
+			MasterInfo:     legacySignatoryOf(old.MasterInfo),
+			AgentForMaster: legacySignatoryOf(old.AgentForMaster),
+			AgentForOwner: Signatory{
+				FirstName: old.AgentForOwner.Properties.FirstName.Type,
+				LastName:  old.AgentForOwner.Properties.LastName.Type,
+				Sig:       old.AgentForOwner.Properties.Sig.Type,
+			},
+			ConditionsForCarriage: old.AgentForOwner.Properties.ConditionsForCarriage.Type,
+		},
+		Id:          legacy.Id,
+		PublicKey:   legacyPublicKey(legacy),
+		Signhash:    legacy.Signhash,
+		Signature:   legacy.Signature,
+		Verified:    false,
+		Transmitted: legacy.Transmitted,
+		Amendment:   legacy.Amendment,
+	}
+
+	if old.NotifyAddress.Type != "" {
+		bftx.Properties.NotifyParty.Address.Lines = []string{old.NotifyAddress.Type}
+	}
+	if old.DescOfGoods.Type != "" || old.GrossWeight.Type != 0 {
+		bftx.Properties.Cargo = []CargoItem{{
+			Description: old.DescOfGoods.Type,
+			GrossWeight: Measure{Value: float64(old.GrossWeight.Type), Unit: "KGM"},
+		}}
+	}
+	return bftx, nil
+}
+
+// legacyPublicKey returns the hex encoded uncompressed P-256 public key of the legacy BF_TX, empty when it has none.
+func legacyPublicKey(legacy legacyBFTX) string {
+	if legacy.PublicKey != "" {
+		return legacy.PublicKey
+	}
+	curve := elliptic.P256()
+	key := legacy.PrivateKey
+	x, y := key.X, key.Y
+	if (x == nil || y == nil) && key.D != nil && key.D.Sign() > 0 && key.D.Cmp(curve.Params().N) < 0 {
+		x, y = curve.ScalarBaseMult(key.D.Bytes())
+	}
+	if x == nil || y == nil || !curve.IsOnCurve(x, y) {
+		return ""
+	}
+	return hex.EncodeToString(elliptic.Marshal(curve, x, y))
+}
+
+// legacyNumber converts a numeric placeholder to its string form, empty when unset.
+func legacyNumber(value legacyInt) string {
+	if value.Type == 0 {
+		return ""
+	}
+	return strconv.Itoa(value.Type)
+}
+
+// legacyDate converts a YYYYMMDD numeric placeholder to an ISO 8601 date.
+func legacyDate(value legacyInt) string {
+	date, err := time.Parse("20060102", strconv.Itoa(value.Type))
+	if err != nil {
+		return legacyNumber(value)
+	}
+	return date.Format("2006-01-02")
+}
+
+func legacySignatoryOf(value legacySignatory) Signatory {
+	return Signatory{
+		FirstName: value.Properties.FirstName.Type,
+		LastName:  value.Properties.LastName.Type,
+		Sig:       value.Properties.Sig.Type,
+	}
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
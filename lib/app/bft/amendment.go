@@ -0,0 +1,296 @@
+// File: ./blockfreight/lib/app/bft/amendment.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/hex"  // Implements hexadecimal encoding and decoding.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+	tendermint "github.com/tendermint/go-common"
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/amendment" // Defines the amendment chain of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"     // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/envelope"  // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle" // Defines the lifecycle of a Bill of Lading.
+)
+
+// RevisionKey returns the state key of the revision of the BF_TX id.
+func RevisionKey(id string) []byte {
+	return []byte(RevisionPrefix + id)
+}
+
+// PendingKey returns the state key of the amendment of the BF_TX id waiting for approvals.
+func PendingKey(id string) []byte {
+	return []byte(PendingPrefix + id)
+}
+
+// ReservedKey returns the state key of the BF_TX id amended by the pending amendment id.
+func ReservedKey(id string) []byte {
+	return []byte(ReservedPrefix + id)
+}
+
+// revision returns the revision of the BF_TX id. A BF_TX created before the revisions is an original issued by the
+// signer of its payload.
+func (app *BftApplication) revision(id string, payload []byte) amendment.Revision {
+	_, value, exists := app.state.Get(RevisionKey(id))
+	if exists {
+		if revision, err := amendment.DecodeRevision(value); err == nil {
+			return revision
+		}
+	}
+	return amendment.NewRevision(id, issuer(payload), 0)
+}
+
+// checkCurrent refuses the transactions on a BF_TX which has been amended, they go to its last revision
+func (app *BftApplication) checkCurrent(id string) types.Result {
+	if next := app.revision(id, nil).Next; next != "" {
+		return ErrBftxInvalidState.SetLog("BF_TX " + id + " was amended by " + next + ".")
+	}
+	return types.OK
+}
+
+// parties returns the parties affected by an amendment of a BF_TX: its issuer, its holder and the parties which signed it
+func (app *BftApplication) parties(id string, payload []byte) []string {
+	parties := []string{issuer(payload)}
+	add := func(party string) {
+		for _, p := range parties {
+			if p == party {
+				return
+			}
+		}
+		parties = append(parties, party)
+	}
+	add(strings.ToLower(app.title(id, payload).Holder))
+
+	prefix := SignaturePrefix + id + ":"
+	app.state.IterateRange([]byte(prefix), nil, true, func(key []byte, value []byte) bool {
+		if !strings.HasPrefix(string(key), prefix) {
+			return true
+		}
+		add(strings.ToLower(strings.TrimPrefix(string(key), prefix)))
+		return false
+	})
+	return parties
+}
+
+// checkAmend checks an envelope which proposes the amendment of a BF_TX, or approves its pending amendment, and returns
+// the proposal with the approval of the sender.
+func (app *BftApplication) checkAmend(env *envelope.Envelope) (amendment.Proposal, types.Result) {
+	var req amendment.Request
+	var proposal amendment.Proposal
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		return proposal, ErrBftxEncoding.SetLog("Invalid amendment encoding: " + err.Error())
+	}
+	_, payload, exists := app.state.Get(BftxKey(req.Parent))
+	if !exists {
+		return proposal, ErrBftxNotFound.SetLog("BF_TX " + req.Parent + " does not exist.")
+	}
+	if res := app.checkCurrent(req.Parent); res.IsErr() {
+		return proposal, res
+	}
+	switch state := app.lifecycle(req.Parent).State; state {
+	case lifecycle.Surrendered, lifecycle.Accomplished, lifecycle.Void:
+		return proposal, ErrBftxInvalidState.SetLog(tendermint.Fmt("BF_TX %s cannot be amended in state %s.", req.Parent, state))
+	}
+	sender := hex.EncodeToString(env.PubKey)
+
+	// An approval adds the sender to the pending amendment
+	if len(req.BFTX) == 0 {
+		_, value, exists := app.state.Get(PendingKey(req.Parent))
+		if exists {
+			proposal, _ = amendment.DecodeProposal(value)
+		}
+		if !exists || proposal.Id != req.Id {
+			return proposal, ErrBftxNotFound.SetLog("BF_TX " + req.Parent + " has no pending amendment " + req.Id + ".")
+		}
+		if app.state.Has(BftxKey(proposal.Id)) {
+			return proposal, ErrBftxDuplicate.SetLog("BF_TX " + proposal.Id + " already exists.")
+		}
+
+		// The title may have been transferred since the proposal, the parties are the current ones
+		proposal.Require(app.parties(req.Parent, payload))
+		return proposal, approve(&proposal, sender, req.Parent)
+	}
+
+	// A proposal replaces the pending amendment, its proposer approves it
+	bftx, res := validateBFTX(req.BFTX)
+	if res.IsErr() {
+		return proposal, res
+	}
+	if bftx.Id == "" {
+		return proposal, ErrBftxInvalidFields.SetLog("BF_TX has no id.")
+	}
+	if app.state.Has(BftxKey(bftx.Id)) {
+		return proposal, ErrBftxDuplicate.SetLog("BF_TX " + bftx.Id + " already exists.")
+	}
+	if _, parent, exists := app.state.Get(ReservedKey(bftx.Id)); exists && string(parent) != req.Parent {
+		return proposal, ErrBftxDuplicate.SetLog("BF_TX " + bftx.Id + " is the pending amendment of BF_TX " + string(parent) + ".")
+	}
+	if !strings.EqualFold(bftx.PublicKey, issuer(payload)) {
+		return proposal, ErrBftxMissingSignature.SetLog("The amendment of BF_TX " + req.Parent + " is not signed by its issuer.")
+	}
+	parent, _ := bf_tx.DecodeBFTX(payload)
+	if bftx.Properties.BolNum != parent.Properties.BolNum {
+		return proposal, ErrBftxInvalidFields.SetLog("The amendment of BF_TX " + req.Parent + " changes its bill of lading number.")
+	}
+	changes := amendment.Changes(parent, bftx)
+	if len(changes) == 0 {
+		return proposal, ErrBftxInvalidFields.SetLog(amendment.ErrNoChanges.Error())
+	}
+
+	proposal = amendment.Proposal{
+		Revision: amendment.Revision{
+			Id:        bftx.Id,
+			Parent:    req.Parent,
+			Proposer:  sender,
+			Height:    app.height + 1,
+			Changes:   changes,
+			Required:  app.parties(req.Parent, payload),
+			Approvals: []string{},
+		},
+		BFTX: req.BFTX,
+	}
+	return proposal, approve(&proposal, sender, req.Parent)
+}
+
+// approve records the approval of the sender on the proposal, which it refuses when the sender is not a party of
+// the BF_TX parent or already approved it.
+func approve(proposal *amendment.Proposal, sender string, parent string) types.Result {
+	switch err := proposal.Approve(sender); err {
+	case nil:
+		return types.OK
+	case amendment.ErrApproved:
+		return ErrBftxDuplicate.SetLog(err.Error())
+	default:
+		return ErrBftxUnauthorized.SetLog("Sender is not a party of BF_TX " + parent + ".")
+	}
+}
+
+// deliverAmend stores the amendment of a BF_TX while approvals are missing, and links it to its parent once every
+// party approved it.
+func (app *BftApplication) deliverAmend(env *envelope.Envelope) types.Result {
+	proposal, res := app.checkAmend(env)
+	if res.IsErr() {
+		return res
+	}
+
+	// The id of the pending amendment is reserved until it is approved or replaced
+	if _, value, exists := app.state.Get(PendingKey(proposal.Parent)); exists {
+		if pending, err := amendment.DecodeProposal(value); err == nil && pending.Id != proposal.Id {
+			app.state.Remove(ReservedKey(pending.Id))
+		}
+	}
+	if missing := proposal.Missing(); len(missing) != 0 {
+		app.state.Set(PendingKey(proposal.Parent), proposal.Encode())
+		app.state.Set(ReservedKey(proposal.Id), []byte(proposal.Parent))
+		return types.NewResultOK([]byte(proposal.Id), tendermint.Fmt("Amendment %s waits for %d approval(s).", proposal.Id, len(missing)))
+	}
+	app.amend(proposal)
+	return types.NewResultOK([]byte(proposal.Id), "")
+}
+
+// amend links an approved amendment to its parent, which hands over its bill of lading number, lifecycle and title
+func (app *BftApplication) amend(proposal amendment.Proposal) {
+	bftx, _ := bf_tx.DecodeBFTX(proposal.BFTX)
+	app.state.Set(BftxKey(proposal.Id), proposal.BFTX)
+	app.state.Set(BolKey(bftx.Properties.BolNum), []byte(proposal.Id))
+
+	_, payload, _ := app.state.Get(BftxKey(proposal.Parent))
+	parent := app.revision(proposal.Parent, payload)
+	parent.Next = proposal.Id
+	app.state.Set(RevisionKey(proposal.Parent), parent.Encode())
+	revision := proposal.Revision
+	revision.Height = app.height + 1
+	app.state.Set(RevisionKey(proposal.Id), revision.Encode())
+
+	if _, value, exists := app.state.Get(LifecycleKey(proposal.Parent)); exists {
+		app.state.Set(LifecycleKey(proposal.Id), value)
+	}
+	if _, value, exists := app.state.Get(TitleKey(proposal.Parent)); exists {
+		app.state.Set(TitleKey(proposal.Id), value)
+	}
+	app.state.Remove(PendingKey(proposal.Parent))
+	app.state.Remove(ReservedKey(proposal.Id))
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -49,7 +49,9 @@ import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"strings" // Implements simple functions to manipulate UTF-8 encoded strings.
+	"encoding/binary" // Implements translation between numbers and byte sequences.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"strings"         // Implements simple functions to manipulate UTF-8 encoded strings.
 
 	// ===============
 	// Tendermint Core
@@ -57,77 +59,286 @@ import (
 	"github.com/tendermint/abci/types"
 	tendermint "github.com/tendermint/go-common"
 	"github.com/tendermint/go-merkle"
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/amendment" // Defines the amendment chain of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"     // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/envelope"  // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle" // Defines the lifecycle of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/title"     // Defines the title of a negotiable Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/validator" // Provides functions to assure the input JSON is correct.
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"    // Provides useful functions to sign BF_TX.
+)
+
+// ABCI codes of the classes of invalid BF_TX which have no code in the ABCI base range.
+const (
+	CodeBftxInvalidState types.CodeType = 301 // The BF_TX is not in a state which allows the transaction.
+	CodeBftxUnauthorized types.CodeType = 302 // The sender has no role allowing the transaction.
+)
+
+// ABCI results returned for each class of invalid BF_TX, each with its own code.
+var (
+	ErrBftxEncoding         = types.ErrBaseEncodingError
+	ErrBftxInvalidFields    = types.ErrBaseInvalidInput
+	ErrBftxMissingSignature = types.ErrUnauthorized
+	ErrBftxInvalidPubKey    = types.ErrBaseInvalidPubKey
+	ErrBftxInvalidSignature = types.ErrBaseInvalidSignature
+	ErrBftxDuplicate        = types.ErrBaseDuplicateAddress
+	ErrBftxNotFound         = types.ErrBaseUnknownAddress
+	ErrBftxBadNonce         = types.ErrBaseInvalidSequence
+	ErrBftxInvalidState     = types.NewError(CodeBftxInvalidState, "Error (bftx) invalid state")
+	ErrBftxUnauthorized     = types.NewError(CodeBftxUnauthorized, "Error (bftx) unauthorized")
+)
+
+// Key prefixes of the application state.
+const (
+	BftxPrefix      = "bftx:"      // BF_TX content by id.
+	SignaturePrefix = "sig:"       // Party signatures by BF_TX id and signer.
+	NoncePrefix     = "nonce:"     // Last nonce used by each sender.
+	BolPrefix       = "bol:"       // Id of the BF_TX holding a bill of lading number, its last revision.
+	LifecyclePrefix = "lifecycle:" // Lifecycle state and history by BF_TX id.
+	TitlePrefix     = "title:"     // Holder and endorsements by BF_TX id.
+	RevisionPrefix  = "revision:"  // Place of each BF_TX in its amendment chain by BF_TX id.
+	PendingPrefix   = "pending:"   // Amendment waiting for approvals by the id of the BF_TX it amends.
+	ReservedPrefix  = "reserved:"  // Id of the BF_TX amended by a pending amendment, by the id of the amendment.
 )
 
 // BftApplication struct
 type BftApplication struct {
 	types.BaseApplication
 
-	state merkle.Tree
+	state    merkle.Tree
+	height   uint64                 // Height of the last committed block.
+	retain   uint64                 // Number of heights kept for the historical queries, 0 for all.
+	versions map[uint64]merkle.Tree // State committed at each height kept.
+	oldest   uint64                 // Oldest height kept.
 }
 
 // NewBftApplication creates a new application
 func NewBftApplication() *BftApplication {
 	state := merkle.NewIAVLTree(0, nil)
-	return &BftApplication{state: state}
+	return &BftApplication{state: state, retain: DefaultRetainHeights, versions: make(map[uint64]merkle.Tree)}
 }
 
 // Info returns information
 func (app *BftApplication) Info() (resInfo types.ResponseInfo) {
-	return types.ResponseInfo{Data: tendermint.Fmt("{\"size\":%v}", app.state.Size()), LastBlockAppHash: app.state.Hash(), LastBlockHeight: uint64(app.state.Height())}
+	return types.ResponseInfo{Data: tendermint.Fmt("{\"size\":%v}", app.state.Size()), LastBlockAppHash: app.state.Hash(), LastBlockHeight: app.height}
 }
 
-// DeliverTx delivers transactions.Transactions are either "key=value" or just arbitrary bytes
+// DeliverTx delivers transactions. Every transaction is an envelope which is dispatched by its type.
 func (app *BftApplication) DeliverTx(tx []byte) types.Result {
-	parts := strings.Split(string(tx), "=")
-	if len(parts) == 2 {
-		app.state.Set([]byte(parts[0]), []byte(parts[1]))
-	} else {
-		app.state.Set(tx, tx)
+	// Second line of defence, in case the tx skipped the mempool
+	env, res := app.checkEnvelope(tx)
+	if res.IsErr() {
+		return res
+	}
+
+	switch env.Type {
+	case envelope.TxCreate:
+		res = app.deliverCreate(env)
+	case envelope.TxAmend:
+		res = app.deliverAmend(env)
+	case envelope.TxSign:
+		res = app.deliverSign(env)
+	case envelope.TxTransition:
+		res = app.deliverTransition(env)
+	case envelope.TxTransfer:
+		res = app.deliverTransfer(env)
+	default:
+		return types.ErrUnknownRequest.SetLog("Transaction type " + env.Type.String() + " is not supported.")
 	}
-	return types.OK
+	if res.IsOK() {
+		app.setNonce(env)
+	}
+	return res
 }
 
 // CheckTx checks a transaction
 func (app *BftApplication) CheckTx(tx []byte) types.Result {
-	return types.OK
+	env, res := app.checkEnvelope(tx)
+	if res.IsErr() {
+		return res
+	}
+
+	switch env.Type {
+	case envelope.TxCreate:
+		_, res = app.checkCreate(env)
+	case envelope.TxAmend:
+		_, res = app.checkAmend(env)
+	case envelope.TxSign:
+		_, res = app.checkSign(env)
+	case envelope.TxTransition:
+		_, _, _, res = app.checkTransition(env)
+	case envelope.TxTransfer:
+		_, res = app.checkTransfer(env)
+	default:
+		res = types.ErrUnknownRequest.SetLog("Transaction type " + env.Type.String() + " is not supported.")
+	}
+	return res
+}
+
+// checkEnvelope decodes the envelope, verifies its signature and checks the sender nonce.
+func (app *BftApplication) checkEnvelope(tx []byte) (*envelope.Envelope, types.Result) {
+	env, err := envelope.Decode(tx)
+	if err != nil {
+		return nil, ErrBftxEncoding.SetLog(err.Error())
+	}
+
+	switch err := env.Verify(); err {
+	case nil:
+	case envelope.ErrInvalidPubKey:
+		return nil, ErrBftxInvalidPubKey.SetLog(err.Error())
+	default:
+		return nil, ErrBftxInvalidSignature.SetLog(err.Error())
+	}
+
+	if env.Nonce <= app.lastNonce(env.PubKey) {
+		return nil, ErrBftxBadNonce.SetLog(tendermint.Fmt("Nonce %d has already been used.", env.Nonce))
+	}
+	return env, types.OK
+}
+
+// checkCreate checks an envelope which creates a new BF_TX.
+func (app *BftApplication) checkCreate(env *envelope.Envelope) (bf_tx.BF_TX, types.Result) {
+	bftx, res := validateBFTX(env.Payload)
+	if res.IsErr() {
+		return bftx, res
+	}
+	if bftx.Id == "" {
+		return bftx, ErrBftxInvalidFields.SetLog("BF_TX has no id.")
+	}
+
+	// The sender must be the party which signed the BF_TX
+	if !strings.EqualFold(hex.EncodeToString(env.PubKey), bftx.PublicKey) {
+		return bftx, ErrBftxMissingSignature.SetLog("Sender is not the signer of the BF_TX.")
+	}
+
+	if app.state.Has(BftxKey(bftx.Id)) {
+		return bftx, ErrBftxDuplicate.SetLog("BF_TX " + bftx.Id + " already exists.")
+	}
+	if _, parent, exists := app.state.Get(ReservedKey(bftx.Id)); exists {
+		return bftx, ErrBftxDuplicate.SetLog("BF_TX " + bftx.Id + " is the pending amendment of BF_TX " + string(parent) + ".")
+	}
+
+	// A bill of lading number belongs to one BF_TX, only its amendments take it over
+	if _, id, exists := app.state.Get(BolKey(bftx.Properties.BolNum)); exists {
+		return bftx, ErrBftxDuplicate.SetLog("Bill of lading " + bftx.Properties.BolNum + " is already held by BF_TX " + string(id) + ".")
+	}
+	return bftx, types.OK
+}
+
+// deliverCreate stores a new BF_TX in the state.
+func (app *BftApplication) deliverCreate(env *envelope.Envelope) types.Result {
+	bftx, res := app.checkCreate(env)
+	if res.IsErr() {
+		return res
+	}
+	app.state.Set(BftxKey(bftx.Id), env.Payload)
+	app.state.Set(BolKey(bftx.Properties.BolNum), []byte(bftx.Id))
+	app.state.Set(LifecycleKey(bftx.Id), lifecycle.NewRecord(hex.EncodeToString(env.PubKey), app.height+1).Encode())
+	app.state.Set(TitleKey(bftx.Id), title.NewRecord(hex.EncodeToString(env.PubKey)).Encode())
+	app.state.Set(RevisionKey(bftx.Id), amendment.NewRevision(bftx.Id, hex.EncodeToString(env.PubKey), app.height+1).Encode())
+	return types.NewResultOK([]byte(bftx.Id), "")
+}
+
+// checkSign checks an envelope which adds the sender signature to an existing BF_TX. The payload is the BF_TX id.
+func (app *BftApplication) checkSign(env *envelope.Envelope) (string, types.Result) {
+	id := string(env.Payload)
+	if !app.state.Has(BftxKey(id)) {
+		return id, ErrBftxNotFound.SetLog("BF_TX " + id + " does not exist.")
+	}
+	if res := app.checkCurrent(id); res.IsErr() {
+		return id, res
+	}
+	if app.state.Has(SignatureKey(id, env.PubKey)) {
+		return id, ErrBftxDuplicate.SetLog("BF_TX " + id + " already signed by this party.")
+	}
+	return id, types.OK
+}
+
+// deliverSign records the sender signature of an existing BF_TX.
+func (app *BftApplication) deliverSign(env *envelope.Envelope) types.Result {
+	id, res := app.checkSign(env)
+	if res.IsErr() {
+		return res
+	}
+	app.state.Set(SignatureKey(id, env.PubKey), env.Encode())
+	return types.NewResultOK([]byte(id), "")
 }
 
-// Commit commits transactions
+// lastNonce returns the last nonce used by a sender.
+func (app *BftApplication) lastNonce(pubkey []byte) uint64 {
+	_, value, exists := app.state.Get(NonceKey(pubkey))
+	if !exists || len(value) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(value)
+}
+
+// setNonce records the nonce of a delivered envelope.
+func (app *BftApplication) setNonce(env *envelope.Envelope) {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, env.Nonce)
+	app.state.Set(NonceKey(env.PubKey), value)
+}
+
+// BftxKey returns the state key of a BF_TX.
+func BftxKey(id string) []byte {
+	return []byte(BftxPrefix + id)
+}
+
+// SignatureKey returns the state key of a party signature of a BF_TX.
+func SignatureKey(id string, pubkey []byte) []byte {
+	return []byte(tendermint.Fmt("%s%s:%X", SignaturePrefix, id, pubkey))
+}
+
+// NonceKey returns the state key of the last nonce of a sender.
+func NonceKey(pubkey []byte) []byte {
+	return []byte(tendermint.Fmt("%s%X", NoncePrefix, pubkey))
+}
+
+// BolKey returns the state key of the BF_TX id of a bill of lading number.
+func BolKey(num string) []byte {
+	return []byte(BolPrefix + num)
+}
+
+// validateBFTX decodes the transaction as a BF_TX, validates its fields and verifies its signature.
+func validateBFTX(tx []byte) (bf_tx.BF_TX, types.Result) {
+	bftx, err := bf_tx.DecodeBFTX(tx)
+	if err != nil {
+		return bftx, ErrBftxEncoding.SetLog("Invalid BF_TX encoding: " + err.Error())
+	}
+
+	if valid, msg := validator.ValidateFields(bftx); !valid {
+		return bftx, ErrBftxInvalidFields.SetLog("Invalid BF_TX fields: " + msg)
+	}
+
+	if crypto.WeakSignature(bftx) {
+		return bftx, ErrBftxInvalidSignature.SetLog("BF_TX signature uses the weak algorithm " + crypto.AlgorithmMD5 + ".")
+	}
+	switch err := crypto.VerifySignature(bftx); err {
+	case nil:
+		return bftx, types.OK
+	case crypto.ErrMissingSignature:
+		return bftx, ErrBftxMissingSignature.SetLog(err.Error())
+	case crypto.ErrInvalidPublicKey:
+		return bftx, ErrBftxInvalidPubKey.SetLog(err.Error())
+	default:
+		return bftx, ErrBftxInvalidSignature.SetLog(err.Error())
+	}
+}
+
+// Commit commits transactions and keeps the committed state for the historical queries
 func (app *BftApplication) Commit() types.Result {
 	newTree := app.state.Copy()
 	hash := newTree.Save()
+	app.height++
+	app.keepVersion()
 	return types.NewResultOK(hash, "")
 }
 
-// Query executes queries and returns the result
-func (app *BftApplication) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
-	if reqQuery.Prove {
-		value, proof, exists := app.state.Proof(reqQuery.Data)
-		resQuery.Index = -1 // TODO make Proof return index
-		resQuery.Key = reqQuery.Data
-		resQuery.Value = value
-		resQuery.Proof = proof
-		if exists {
-			resQuery.Log = "exists"
-		} else {
-			resQuery.Log = "does not exist"
-		}
-		return
-	}
-
-	index, value, exists := app.state.Get(reqQuery.Data)
-	resQuery.Index = int64(index)
-	resQuery.Value = value
-	if exists {
-		resQuery.Log = "exists"
-	} else {
-		resQuery.Log = "does not exist"
-	}
-	return
-
-}
-
 // =================================================
 // Blockfreight™ | The blockchain of global freight.
 // =================================================
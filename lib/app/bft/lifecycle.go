@@ -0,0 +1,173 @@
+// File: ./blockfreight/lib/app/bft/lifecycle.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/hex"  // Implements hexadecimal encoding and decoding.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+	tendermint "github.com/tendermint/go-common"
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/envelope"  // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle" // Defines the lifecycle of a Bill of Lading.
+)
+
+// LifecycleKey returns the state key of the lifecycle of a BF_TX.
+func LifecycleKey(id string) []byte {
+	return []byte(LifecyclePrefix + id)
+}
+
+// lifecycle returns the lifecycle of a BF_TX. The BF_TX created before the lifecycle was enforced are drafts.
+func (app *BftApplication) lifecycle(id string) lifecycle.Record {
+	_, value, exists := app.state.Get(LifecycleKey(id))
+	if !exists {
+		return lifecycle.Record{State: lifecycle.Draft}
+	}
+	record, err := lifecycle.DecodeRecord(value)
+	if err != nil {
+		return lifecycle.Record{State: lifecycle.Draft}
+	}
+	return record
+}
+
+// checkTransition checks an envelope which moves a BF_TX to another state. The payload is a lifecycle.Request;
+// the sender must have the role the transition requires.
+func (app *BftApplication) checkTransition(env *envelope.Envelope) (lifecycle.Request, lifecycle.Record, lifecycle.Transition, types.Result) {
+	var req lifecycle.Request
+	var record lifecycle.Record
+	var transition lifecycle.Transition
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		return req, record, transition, ErrBftxEncoding.SetLog("Invalid transition encoding: " + err.Error())
+	}
+	_, payload, exists := app.state.Get(BftxKey(req.Id))
+	if !exists {
+		return req, record, transition, ErrBftxNotFound.SetLog("BF_TX " + req.Id + " does not exist.")
+	}
+	if res := app.checkCurrent(req.Id); res.IsErr() {
+		return req, record, transition, res
+	}
+	if _, err := lifecycle.Parse(string(req.State)); err != nil {
+		return req, record, transition, ErrBftxInvalidState.SetLog("Unknown state " + string(req.State) + ".")
+	}
+
+	record = app.lifecycle(req.Id)
+	transition, err := lifecycle.Find(record.State, req.State)
+	if err != nil {
+		return req, record, transition, ErrBftxInvalidState.SetLog(tendermint.Fmt("BF_TX %s cannot go from %s to %s.", req.Id, record.State, req.State))
+	}
+	if !app.hasRole(req.Id, payload, env.PubKey, transition.Role) {
+		return req, record, transition, ErrBftxUnauthorized.SetLog(tendermint.Fmt("Sender may not move BF_TX %s from %s to %s.", req.Id, record.State, req.State))
+	}
+	return req, record, transition, types.OK
+}
+
+// deliverTransition records the transition of a BF_TX to another state. A surrender returns the bill to the
+// carrier.
+func (app *BftApplication) deliverTransition(env *envelope.Envelope) types.Result {
+	req, record, transition, res := app.checkTransition(env)
+	if res.IsErr() {
+		return res
+	}
+	if transition.To == lifecycle.Surrendered {
+		app.surrender(req.Id, hex.EncodeToString(env.PubKey))
+		return types.NewResultOK([]byte(req.Id), "")
+	}
+	record.Apply(transition, hex.EncodeToString(env.PubKey), app.height+1)
+	app.state.Set(LifecycleKey(req.Id), record.Encode())
+	return types.NewResultOK([]byte(req.Id), "")
+}
+
+// hasRole reports whether the sender has a role on the BF_TX stored as payload: the issuer created it, the
+// holder holds its title.
+func (app *BftApplication) hasRole(id string, payload []byte, pubkey []byte, role lifecycle.Role) bool {
+	sender := hex.EncodeToString(pubkey)
+	switch role {
+	case lifecycle.RoleIssuer:
+		return strings.EqualFold(sender, issuer(payload))
+	case lifecycle.RoleHolder:
+		return strings.EqualFold(sender, app.title(id, payload).Holder)
+	}
+	return false
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,241 @@
+// File: ./blockfreight/lib/bft/persistent_bft.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"log"           // Implements a simple logging package.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+	dbm "github.com/tendermint/go-db"
+	"github.com/tendermint/go-merkle"
+)
+
+// lastBlockKey is the key where the last committed block info is stored.
+var lastBlockKey = []byte("lastblock")
+
+// PersistentBftApplication struct
+type PersistentBftApplication struct {
+	app      *BftApplication
+	db       dbm.DB
+	versions *versionDB // DB of the state tree, which keeps the nodes of the heights kept.
+
+	// latest received block header
+	blockHeader *types.Header
+}
+
+// LastBlockInfo keeps the height and the app hash of the last committed block.
+type LastBlockInfo struct {
+	Height  uint64
+	AppHash []byte
+}
+
+// NewPersistentBftApplication creates a new application which stores its state in a LevelDB located in dbDir.
+func NewPersistentBftApplication(dbDir string) *PersistentBftApplication {
+	db := dbm.NewDB("bft", dbm.GoLevelDBBackendStr, dbDir)
+	lastBlock := LoadLastBlock(db)
+	versions := newVersionDB(db, lastBlock.Height)
+
+	state := merkle.NewIAVLTree(0, versions)
+	state.Load(lastBlock.AppHash)
+
+	log.Printf("Loaded state: height %d, root %X\n", lastBlock.Height, state.Hash())
+
+	app := &BftApplication{
+		state:    state,
+		height:   lastBlock.Height,
+		retain:   DefaultRetainHeights,
+		versions: make(map[uint64]merkle.Tree),
+		oldest:   versions.oldest,
+	}
+
+	// Reload the heights kept by the previous runs
+	for height := versions.oldest; height < lastBlock.Height; height++ {
+		if height == 0 {
+			continue
+		}
+		version := merkle.NewIAVLTree(0, versions)
+		version.Load(db.Get(rootKey(height)))
+		app.versions[height] = version
+	}
+	if lastBlock.Height > 0 {
+		app.versions[lastBlock.Height] = state.Copy()
+	}
+
+	return &PersistentBftApplication{
+		app:      app,
+		db:       db,
+		versions: versions,
+	}
+}
+
+// Info returns information, including the last committed height and app hash
+func (app *PersistentBftApplication) Info() (resInfo types.ResponseInfo) {
+	resInfo = app.app.Info()
+	lastBlock := LoadLastBlock(app.db)
+	resInfo.LastBlockHeight = lastBlock.Height
+	resInfo.LastBlockAppHash = lastBlock.AppHash
+	return resInfo
+}
+
+// SetOption sets an option on the application
+func (app *PersistentBftApplication) SetOption(key string, value string) (log string) {
+	return app.app.SetOption(key, value)
+}
+
+// DeliverTx delivers transactions
+func (app *PersistentBftApplication) DeliverTx(tx []byte) types.Result {
+	return app.app.DeliverTx(tx)
+}
+
+// CheckTx checks a transaction
+func (app *PersistentBftApplication) CheckTx(tx []byte) types.Result {
+	return app.app.CheckTx(tx)
+}
+
+// Commit saves the state tree to disk, records the last committed block and prunes the heights out of the
+// retention window
+func (app *PersistentBftApplication) Commit() types.Result {
+	lastBlock := LoadLastBlock(app.db)
+	if app.blockHeader != nil {
+		lastBlock.Height = app.blockHeader.Height
+	} else {
+		lastBlock.Height++
+	}
+
+	app.versions.height = lastBlock.Height
+	appHash := app.app.state.Save()
+	lastBlock.AppHash = appHash // this hash will be in the next block header
+	app.db.SetSync(rootKey(lastBlock.Height), appHash)
+
+	if err := SaveLastBlock(app.db, lastBlock); err != nil {
+		return types.ErrInternalError.SetLog(err.Error())
+	}
+	app.app.height = lastBlock.Height
+	app.versions.prune(app.app.keepVersion())
+	return types.NewResultOK(appHash, "")
+}
+
+// Query executes queries and returns the result
+func (app *PersistentBftApplication) Query(reqQuery types.RequestQuery) types.ResponseQuery {
+	return app.app.Query(reqQuery)
+}
+
+// InitChain initializes the blockchain with validators
+func (app *PersistentBftApplication) InitChain(validators []*types.Validator) {
+	app.app.InitChain(validators)
+}
+
+// BeginBlock tracks the block hash and header information
+func (app *PersistentBftApplication) BeginBlock(hash []byte, header *types.Header) {
+	app.blockHeader = header
+}
+
+// EndBlock signals the end of a block
+func (app *PersistentBftApplication) EndBlock(height uint64) (resEndBlock types.ResponseEndBlock) {
+	return app.app.EndBlock(height)
+}
+
+// Close closes the underlying database
+func (app *PersistentBftApplication) Close() {
+	app.db.Close()
+}
+
+// LoadLastBlock gets the last committed block info from the DB.
+func LoadLastBlock(db dbm.DB) (lastBlock LastBlockInfo) {
+	buf := db.Get(lastBlockKey)
+	if len(buf) != 0 {
+		if err := json.Unmarshal(buf, &lastBlock); err != nil {
+			// Data has been corrupted or its spec has changed
+			log.Fatalf("Data has been corrupted or its spec has changed: %v\n", err)
+		}
+	}
+	return lastBlock
+}
+
+// SaveLastBlock stores the last committed block info in the DB.
+func SaveLastBlock(db dbm.DB, lastBlock LastBlockInfo) error {
+	buf, err := json.Marshal(lastBlock)
+	if err != nil {
+		return err
+	}
+	db.SetSync(lastBlockKey, buf)
+	return nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,230 @@
+// File: ./blockfreight/lib/app/bft/query.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"strconv"       // Implements conversions to and from string representations of basic data types.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+	"github.com/tendermint/go-merkle"
+)
+
+// Query paths of the application state.
+const (
+	PathBftx      = "/bftx/"        // BF_TX by id: /bftx/{id}.
+	PathBol       = "/bftx/by-bol/" // BF_TX holding a bill of lading number, its last revision: /bftx/by-bol/{num}.
+	PathLifecycle = "/lifecycle/"   // Lifecycle state and history of a BF_TX: /lifecycle/{id}.
+	PathTitle     = "/title/"       // Holder and endorsements of a BF_TX: /title/{id}.
+	PathRevision  = "/revision/"    // Place of a BF_TX in its amendment chain: /revision/{id}.
+	PathPending   = "/pending/"     // Amendment of a BF_TX waiting for approvals: /pending/{id}.
+	PathState     = "/state"        // State key given in the query data.
+	PathSize      = "/size"         // Number of keys in the state.
+)
+
+// Errors of the proof verification.
+var (
+	ErrProofMissing = errors.New("The response has no proof.")
+	ErrProofInvalid = errors.New("The proof does not match the key, the value and the app hash.")
+)
+
+// BolProof is the proof of a query by bill of lading number: the number maps to the id of the BF_TX which holds it,
+// and that id to the BF_TX.
+type BolProof struct {
+	Bol  []byte `json:"bol"`  // IAVL proof of the BF_TX id under the bill of lading number.
+	Bftx []byte `json:"bftx"` // IAVL proof of the BF_TX under its id.
+}
+
+// Encode returns the JSON encoding of the proof.
+func (proof BolProof) Encode() []byte {
+	buf, _ := json.Marshal(proof)
+	return buf
+}
+
+// Query executes queries and returns the result. The path selects what is read, an empty path reads the state
+// key in the query data as PathState. Height 0 reads the current state, another height the state committed at
+// that height while it is kept.
+func (app *BftApplication) Query(reqQuery types.RequestQuery) (resQuery types.ResponseQuery) {
+	state, height := app.state, app.height
+	if reqQuery.Height != 0 {
+		version, log, ok := app.version(reqQuery.Height)
+		if !ok {
+			resQuery.Code = types.CodeType_BaseInvalidInput
+			resQuery.Log = log
+			return
+		}
+		state, height = version, reqQuery.Height
+	}
+
+	path := reqQuery.Path
+	switch {
+	case path == "" || path == PathState:
+		resQuery = queryKey(state, reqQuery.Data, reqQuery.Prove)
+	case path == PathSize:
+		resQuery.Value = []byte(strconv.Itoa(state.Size()))
+	case strings.HasPrefix(path, PathBol):
+		// The proof covers the bill of lading number and the BF_TX which holds it
+		num := strings.TrimPrefix(path, PathBol)
+		_, id, exists := state.Get(BolKey(num))
+		if !exists {
+			resQuery.Log = "does not exist"
+			break
+		}
+		resQuery = queryKey(state, BftxKey(string(id)), reqQuery.Prove)
+		if reqQuery.Prove && len(resQuery.Proof) != 0 {
+			_, bolProof, _ := state.Proof(BolKey(num))
+			resQuery.Proof = BolProof{Bol: bolProof, Bftx: resQuery.Proof}.Encode()
+		}
+	case strings.HasPrefix(path, PathLifecycle):
+		resQuery = queryKey(state, LifecycleKey(strings.TrimPrefix(path, PathLifecycle)), reqQuery.Prove)
+	case strings.HasPrefix(path, PathTitle):
+		resQuery = queryKey(state, TitleKey(strings.TrimPrefix(path, PathTitle)), reqQuery.Prove)
+	case strings.HasPrefix(path, PathRevision):
+		resQuery = queryKey(state, RevisionKey(strings.TrimPrefix(path, PathRevision)), reqQuery.Prove)
+	case strings.HasPrefix(path, PathPending):
+		resQuery = queryKey(state, PendingKey(strings.TrimPrefix(path, PathPending)), reqQuery.Prove)
+	case strings.HasPrefix(path, PathBftx):
+		resQuery = queryKey(state, BftxKey(strings.TrimPrefix(path, PathBftx)), reqQuery.Prove)
+	default:
+		resQuery.Code = types.CodeType_UnknownRequest
+		resQuery.Log = "Query path " + path + " is not supported."
+		return
+	}
+	resQuery.Height = height
+	return
+}
+
+// queryKey reads a state key, with its IAVL proof when prove is set.
+func queryKey(state merkle.Tree, key []byte, prove bool) (resQuery types.ResponseQuery) {
+	var exists bool
+	resQuery.Key = key
+	if prove {
+		resQuery.Index = -1 // TODO make Proof return index
+		resQuery.Value, resQuery.Proof, exists = state.Proof(key)
+	} else {
+		var index int
+		index, resQuery.Value, exists = state.Get(key)
+		resQuery.Index = int64(index)
+	}
+	if exists {
+		resQuery.Log = "exists"
+	} else {
+		resQuery.Log = "does not exist"
+	}
+	return
+}
+
+// VerifyProof checks the IAVL proof of a query response: the key holds the value in the state of the app hash.
+func VerifyProof(key, value, proof, appHash []byte) (err error) {
+	if len(proof) == 0 {
+		return ErrProofMissing
+	}
+
+	// go-wire panics on some malformed input
+	defer func() {
+		if recover() != nil {
+			err = ErrProofInvalid
+		}
+	}()
+	iavlProof, err := merkle.ReadProof(proof)
+	if err != nil || !iavlProof.Verify(key, value, appHash) {
+		return ErrProofInvalid
+	}
+	return nil
+}
+
+// VerifyBolProof checks the proof of a query by the bill of lading number num: in the state of the app hash, num is
+// held by the BF_TX of the key, which holds the value.
+func VerifyBolProof(num string, key, value, proof, appHash []byte) error {
+	if len(proof) == 0 {
+		return ErrProofMissing
+	}
+	var bolProof BolProof
+	if err := json.Unmarshal(proof, &bolProof); err != nil || !strings.HasPrefix(string(key), BftxPrefix) {
+		return ErrProofInvalid
+	}
+	id := strings.TrimPrefix(string(key), BftxPrefix)
+	if err := VerifyProof(BolKey(num), []byte(id), bolProof.Bol, appHash); err != nil {
+		return ErrProofInvalid
+	}
+	return VerifyProof(key, value, bolProof.Bftx, appHash)
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,209 @@
+// File: ./blockfreight/lib/app/bft/title.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"encoding/json"   // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"strings"         // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	"github.com/tendermint/abci/types"
+	tendermint "github.com/tendermint/go-common"
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"     // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/envelope"  // Defines the envelope which wraps every transaction.
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle" // Defines the lifecycle of a Bill of Lading.
+	"github.com/blockfreight/go-bftx/lib/app/title"     // Defines the title of a negotiable Bill of Lading.
+)
+
+// TitleKey returns the state key of the title of a BF_TX.
+func TitleKey(id string) []byte {
+	return []byte(TitlePrefix + id)
+}
+
+// issuer returns the hex public key of the party which created the BF_TX stored as payload.
+func issuer(payload []byte) string {
+	bftx, err := bf_tx.DecodeBFTX(payload)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(bftx.PublicKey)
+}
+
+// title returns the title of the BF_TX stored as payload. The issuer holds the BF_TX created before the titles
+// were recorded.
+func (app *BftApplication) title(id string, payload []byte) title.Record {
+	_, value, exists := app.state.Get(TitleKey(id))
+	if exists {
+		if record, err := title.DecodeRecord(value); err == nil {
+			return record
+		}
+	}
+	return title.NewRecord(issuer(payload))
+}
+
+// checkTransfer checks an envelope which transfers the title of a BF_TX. The payload is a title.Request; the
+// sender must be the holder. Only the bills made out to order are endorsed, from issue to discharge, and only a
+// discharged bill is surrendered.
+func (app *BftApplication) checkTransfer(env *envelope.Envelope) (title.Request, types.Result) {
+	var req title.Request
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		return req, ErrBftxEncoding.SetLog("Invalid transfer encoding: " + err.Error())
+	}
+	_, payload, exists := app.state.Get(BftxKey(req.Id))
+	if !exists {
+		return req, ErrBftxNotFound.SetLog("BF_TX " + req.Id + " does not exist.")
+	}
+	if res := app.checkCurrent(req.Id); res.IsErr() {
+		return req, res
+	}
+
+	record := app.title(req.Id, payload)
+	switch err := record.Check(req); err {
+	case nil:
+	case title.ErrSurrendered:
+		return req, ErrBftxInvalidState.SetLog("BF_TX " + req.Id + ": " + err.Error())
+	default:
+		return req, ErrBftxInvalidFields.SetLog(err.Error())
+	}
+	if req.To != "" {
+		to, err := hex.DecodeString(req.To)
+		if x, _ := elliptic.Unmarshal(elliptic.P256(), to); err != nil || x == nil {
+			return req, ErrBftxInvalidPubKey.SetLog("Endorsee " + req.To + " is not a hex P-256 public key.")
+		}
+		req.To = hex.EncodeToString(to)
+	}
+
+	// Only the holder transfers the title, so each holder transfers it once
+	if !strings.EqualFold(hex.EncodeToString(env.PubKey), record.Holder) {
+		return req, ErrBftxUnauthorized.SetLog("Sender is not the holder of BF_TX " + req.Id + ".")
+	}
+
+	state := app.lifecycle(req.Id).State
+	if req.Kind == title.KindSurrender {
+		if _, err := lifecycle.Find(state, lifecycle.Surrendered); err != nil {
+			return req, ErrBftxInvalidState.SetLog(tendermint.Fmt("BF_TX %s cannot be surrendered in state %s.", req.Id, state))
+		}
+		return req, types.OK
+	}
+	bftx, _ := bf_tx.DecodeBFTX(payload)
+	if bftx.Properties.Consignee.Name != "" {
+		return req, ErrBftxInvalidState.SetLog("BF_TX " + req.Id + " is a straight bill, it cannot be endorsed.")
+	}
+	switch state {
+	case lifecycle.Issued, lifecycle.ShippedOnBoard, lifecycle.InTransit, lifecycle.Discharged:
+		return req, types.OK
+	}
+	return req, ErrBftxInvalidState.SetLog(tendermint.Fmt("BF_TX %s cannot be endorsed in state %s.", req.Id, state))
+}
+
+// deliverTransfer records the transfer of the title of a BF_TX.
+func (app *BftApplication) deliverTransfer(env *envelope.Envelope) types.Result {
+	req, res := app.checkTransfer(env)
+	if res.IsErr() {
+		return res
+	}
+	if req.Kind == title.KindSurrender {
+		app.surrender(req.Id, hex.EncodeToString(env.PubKey))
+		return types.NewResultOK([]byte(req.Id), "")
+	}
+	_, payload, _ := app.state.Get(BftxKey(req.Id))
+	record := app.title(req.Id, payload)
+	record.Apply(req, issuer(payload), app.height+1)
+	app.state.Set(TitleKey(req.Id), record.Encode())
+	return types.NewResultOK([]byte(req.Id), "")
+}
+
+// surrender returns a discharged BF_TX to the carrier: its title and its lifecycle record the surrender together.
+func (app *BftApplication) surrender(id string, by string) {
+	_, payload, _ := app.state.Get(BftxKey(id))
+	record := app.title(id, payload)
+	record.Apply(title.Request{Id: id, Kind: title.KindSurrender}, issuer(payload), app.height+1)
+	app.state.Set(TitleKey(id), record.Encode())
+
+	history := app.lifecycle(id)
+	transition, _ := lifecycle.Find(history.State, lifecycle.Surrendered)
+	history.Apply(transition, by, app.height+1)
+	app.state.Set(LifecycleKey(id), history.Encode())
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,280 @@
+// File: ./blockfreight/lib/app/bft/versions.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package bft
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"strconv"       // Implements conversions to and from string representations of basic data types.
+
+	// ===============
+	// Tendermint Core
+	// ===============
+	tendermint "github.com/tendermint/go-common"
+	dbm "github.com/tendermint/go-db"
+	"github.com/tendermint/go-merkle"
+)
+
+// OptionRetainHeights is the SetOption key of the number of past heights kept for the historical queries.
+const OptionRetainHeights = "retain_heights"
+
+// DefaultRetainHeights is the number of heights kept by default, 0 keeps every height.
+const DefaultRetainHeights = 1000
+
+// oldestKey is the key where the oldest kept height is stored.
+var oldestKey = []byte("version:oldest")
+
+// SetOption sets an option on the application. retain_heights is the number of committed heights which can be
+// queried, the last one included; 0 keeps every height. The older heights are pruned on the next commit.
+func (app *BftApplication) SetOption(key string, value string) (log string) {
+	switch key {
+	case OptionRetainHeights:
+		retain, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return "Invalid " + OptionRetainHeights + " " + value + "."
+		}
+		app.retain = retain
+		return ""
+	default:
+		return "Option " + key + " is not supported."
+	}
+}
+
+// keepVersion keeps the state committed at the current height, prunes the heights out of the retention window
+// and returns the oldest height kept.
+func (app *BftApplication) keepVersion() uint64 {
+	if len(app.versions) == 0 {
+		app.oldest = app.height
+	}
+	app.versions[app.height] = app.state.Copy()
+	if app.retain != 0 && app.height-app.oldest >= app.retain {
+		oldest := app.height - app.retain + 1
+		for height := app.oldest; height < oldest; height++ {
+			delete(app.versions, height)
+		}
+		app.oldest = oldest
+	}
+	return app.oldest
+}
+
+// version returns the state committed at a height. The result is not OK when the height is not committed yet
+// or has been pruned.
+func (app *BftApplication) version(height uint64) (merkle.Tree, string, bool) {
+	if height > app.height {
+		return nil, tendermint.Fmt("Height %d is not committed yet, the last height is %d.", height, app.height), false
+	}
+	state, ok := app.versions[height]
+	if !ok {
+		return nil, tendermint.Fmt("Height %d has been pruned, the oldest height kept is %d.", height, app.oldest), false
+	}
+	return state, "", true
+}
+
+// rootKey returns the key where the app hash of a height is stored.
+func rootKey(height uint64) []byte {
+	return []byte(tendermint.Fmt("version:root:%d", height))
+}
+
+// orphansKey returns the key where the nodes deleted by the commit of a height are listed.
+func orphansKey(height uint64) []byte {
+	return []byte(tendermint.Fmt("version:orphans:%d", height))
+}
+
+// versionDB is the DB of the persistent state tree. The tree deletes the nodes which its last version does not
+// use any more; versionDB keeps them, listed by the height of the commit which deleted them, until the heights
+// which use them are pruned.
+type versionDB struct {
+	dbm.DB
+
+	height  uint64                         // Height being committed.
+	oldest  uint64                         // Oldest height kept.
+	orphans map[uint64]map[string]struct{} // Nodes kept, by the height of the commit which deleted them.
+	deleted map[string]uint64              // Height of the commit which deleted each node kept.
+	dirty   map[uint64]bool                // Heights whose list changed since the last write.
+}
+
+// newVersionDB wraps db and loads the lists of the nodes kept for the heights from the oldest one to height.
+func newVersionDB(db dbm.DB, height uint64) *versionDB {
+	vdb := &versionDB{
+		DB:      db,
+		height:  height,
+		oldest:  height,
+		orphans: make(map[uint64]map[string]struct{}),
+		deleted: make(map[string]uint64),
+		dirty:   make(map[uint64]bool),
+	}
+	if buf := db.Get(oldestKey); len(buf) != 0 {
+		vdb.oldest, _ = strconv.ParseUint(string(buf), 10, 64)
+	}
+	for h := vdb.oldest + 1; h <= height; h++ {
+		var nodes [][]byte
+		if buf := db.Get(orphansKey(h)); len(buf) != 0 && json.Unmarshal(buf, &nodes) == nil {
+			for _, node := range nodes {
+				vdb.keep(h, string(node))
+			}
+		}
+	}
+	return vdb
+}
+
+// keep records a node deleted by the commit of a height.
+func (vdb *versionDB) keep(height uint64, node string) {
+	if vdb.orphans[height] == nil {
+		vdb.orphans[height] = make(map[string]struct{})
+	}
+	vdb.orphans[height][node] = struct{}{}
+	vdb.deleted[node] = height
+}
+
+// revive forgets a kept node which the tree saves again.
+func (vdb *versionDB) revive(node string) {
+	if height, ok := vdb.deleted[node]; ok {
+		delete(vdb.orphans[height], node)
+		delete(vdb.deleted, node)
+		vdb.dirty[height] = true
+	}
+}
+
+// writeOrphans adds the lists which changed to batch.
+func (vdb *versionDB) writeOrphans(batch dbm.Batch) {
+	for height := range vdb.dirty {
+		nodes := make([][]byte, 0, len(vdb.orphans[height]))
+		for node := range vdb.orphans[height] {
+			nodes = append(nodes, []byte(node))
+		}
+		if len(nodes) == 0 {
+			batch.Delete(orphansKey(height))
+			continue
+		}
+		buf, _ := json.Marshal(nodes)
+		batch.Set(orphansKey(height), buf)
+	}
+	vdb.dirty = make(map[uint64]bool)
+}
+
+// prune deletes the app hashes of the heights older than oldest, and the nodes which only they use.
+func (vdb *versionDB) prune(oldest uint64) {
+	if oldest <= vdb.oldest {
+		return
+	}
+	batch := vdb.DB.NewBatch()
+	for height := vdb.oldest; height < oldest; height++ {
+		batch.Delete(rootKey(height))
+	}
+
+	// The nodes deleted by the commit of a height were used by the heights before it only
+	for height := vdb.oldest + 1; height <= oldest; height++ {
+		for node := range vdb.orphans[height] {
+			batch.Delete([]byte(node))
+			delete(vdb.deleted, node)
+		}
+		delete(vdb.orphans, height)
+		delete(vdb.dirty, height)
+		batch.Delete(orphansKey(height))
+	}
+	batch.Set(oldestKey, []byte(strconv.FormatUint(oldest, 10)))
+	batch.Write()
+	vdb.oldest = oldest
+}
+
+// NewBatch returns a batch which keeps the nodes it deletes.
+func (vdb *versionDB) NewBatch() dbm.Batch {
+	return &versionBatch{Batch: vdb.DB.NewBatch(), vdb: vdb}
+}
+
+// versionBatch is a batch of the state tree, see versionDB.
+type versionBatch struct {
+	dbm.Batch
+	vdb *versionDB
+}
+
+// Set writes a node, which is not deleted any more if it was kept.
+func (b *versionBatch) Set(key, value []byte) {
+	b.vdb.revive(string(key))
+	b.Batch.Set(key, value)
+}
+
+// Delete keeps the node, listed by the height being committed.
+func (b *versionBatch) Delete(key []byte) {
+	b.vdb.keep(b.vdb.height, string(key))
+	b.vdb.dirty[b.vdb.height] = true
+}
+
+// Write writes the nodes and the lists of the nodes kept, together.
+func (b *versionBatch) Write() {
+	b.vdb.writeOrphans(b.Batch)
+	b.Batch.Write()
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
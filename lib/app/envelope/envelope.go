@@ -0,0 +1,275 @@
+// File: ./blockfreight/lib/envelope/envelope.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package envelope defines the versioned, binary-safe envelope which wraps every transaction sent to the Blockfreight™ Network.
+package envelope
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"           // Implements functions for the manipulation of byte slices.
+	"crypto/ecdsa"    // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
+	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
+	"crypto/rand"     // Implements a cryptographically secure pseudorandom number generator.
+	"crypto/sha256"   // Implements the SHA256 Algorithm for Hash.
+	"encoding/binary" // Implements translation between numbers and byte sequences.
+	"errors"          // Implements functions to manipulate errors.
+	"fmt"             // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"math/big"        // Implements arbitrary-precision arithmetic (big numbers).
+)
+
+// Version is the current version of the envelope encoding.
+const Version uint8 = 1
+
+// coordinateSize is the size in bytes of each half (r and s) of a P-256 signature.
+const coordinateSize = 32
+
+// TxType identifies the kind of operation carried by an envelope.
+type TxType uint8
+
+// Transaction types understood by the Blockfreight™ application.
+const (
+	TxCreate     TxType = 1 // Create a new Bill of Lading.
+	TxAmend      TxType = 2 // Amend an existing Bill of Lading.
+	TxSign       TxType = 3 // Add a party signature to an existing Bill of Lading.
+	TxTransfer   TxType = 4 // Transfer the title of an existing Bill of Lading.
+	TxTransition TxType = 5 // Move an existing Bill of Lading to another state of its lifecycle.
+)
+
+var (
+	// ErrUnsupportedVersion is returned when the envelope version is unknown.
+	ErrUnsupportedVersion = errors.New("Envelope version is not supported.")
+	// ErrMalformed is returned when the envelope bytes cannot be decoded.
+	ErrMalformed = errors.New("Envelope is malformed.")
+	// ErrInvalidPubKey is returned when the sender public key is not a P-256 point.
+	ErrInvalidPubKey = errors.New("Envelope sender public key is not valid.")
+	// ErrInvalidSignature is returned when the envelope signature does not verify.
+	ErrInvalidSignature = errors.New("Envelope signature is not valid.")
+)
+
+// Envelope wraps the payload of a transaction with its type, sender, nonce and signature.
+type Envelope struct {
+	Version   uint8
+	Type      TxType
+	PubKey    []byte // Sender public key, uncompressed P-256 point.
+	Nonce     uint64
+	Payload   []byte
+	Signature []byte // Fixed-width r || s over SignBytes.
+}
+
+// String returns the name of the transaction type.
+func (t TxType) String() string {
+	switch t {
+	case TxCreate:
+		return "create"
+	case TxAmend:
+		return "amend"
+	case TxSign:
+		return "sign"
+	case TxTransfer:
+		return "transfer"
+	case TxTransition:
+		return "transition"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// New creates an unsigned envelope of the current version.
+func New(txType TxType, nonce uint64, payload []byte) *Envelope {
+	return &Envelope{Version: Version, Type: txType, Nonce: nonce, Payload: payload}
+}
+
+// SignBytes returns the bytes covered by the envelope signature, which is everything but the signature itself.
+func (env *Envelope) SignBytes() []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteByte(env.Version)
+	buf.WriteByte(byte(env.Type))
+	writeBytes(buf, env.PubKey)
+	binary.Write(buf, binary.BigEndian, env.Nonce)
+	writeBytes(buf, env.Payload)
+	return buf.Bytes()
+}
+
+// Encode returns the binary encoding of the envelope.
+func (env *Envelope) Encode() []byte {
+	buf := bytes.NewBuffer(env.SignBytes())
+	writeBytes(buf, env.Signature)
+	return buf.Bytes()
+}
+
+// Decode parses the binary encoding of an envelope.
+func Decode(data []byte) (*Envelope, error) {
+	r := bytes.NewReader(data)
+	env := new(Envelope)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	env.Version = version
+
+	txType, err := r.ReadByte()
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	env.Type = TxType(txType)
+
+	if env.PubKey, err = readBytes(r); err != nil {
+		return nil, ErrMalformed
+	}
+	if err = binary.Read(r, binary.BigEndian, &env.Nonce); err != nil {
+		return nil, ErrMalformed
+	}
+	if env.Payload, err = readBytes(r); err != nil {
+		return nil, ErrMalformed
+	}
+	if env.Signature, err = readBytes(r); err != nil {
+		return nil, ErrMalformed
+	}
+	if r.Len() != 0 {
+		return nil, ErrMalformed
+	}
+	return env, nil
+}
+
+// Sign sets the sender public key and signs the envelope with the given private key.
+func (env *Envelope) Sign(privatekey *ecdsa.PrivateKey) error {
+	env.PubKey = elliptic.Marshal(elliptic.P256(), privatekey.X, privatekey.Y)
+
+	hash := sha256.Sum256(env.SignBytes())
+	r, s, err := ecdsa.Sign(rand.Reader, privatekey, hash[:])
+	if err != nil {
+		return err
+	}
+
+	env.Signature = make([]byte, 2*coordinateSize)
+	r.FillBytes(env.Signature[:coordinateSize])
+	s.FillBytes(env.Signature[coordinateSize:])
+	return nil
+}
+
+// Verify checks the envelope signature against the sender public key.
+func (env *Envelope) Verify() error {
+	pubkey, err := env.SenderKey()
+	if err != nil {
+		return err
+	}
+	if len(env.Signature) != 2*coordinateSize {
+		return ErrInvalidSignature
+	}
+
+	r := new(big.Int).SetBytes(env.Signature[:coordinateSize])
+	s := new(big.Int).SetBytes(env.Signature[coordinateSize:])
+	hash := sha256.Sum256(env.SignBytes())
+	if !ecdsa.Verify(pubkey, hash[:], r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SenderKey parses the sender public key of the envelope.
+func (env *Envelope) SenderKey() (*ecdsa.PublicKey, error) {
+	x, y := elliptic.Unmarshal(elliptic.P256(), env.PubKey)
+	if x == nil {
+		return nil, ErrInvalidPubKey
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+// writeBytes writes a length-prefixed byte slice.
+func writeBytes(buf *bytes.Buffer, b []byte) {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(b)))
+	buf.Write(length[:n])
+	buf.Write(b)
+}
+
+// readBytes reads a length-prefixed byte slice.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(r.Len()) {
+		return nil, ErrMalformed
+	}
+	b := make([]byte, length)
+	if _, err := r.Read(b); err != nil && length > 0 {
+		return nil, err
+	}
+	return b, nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
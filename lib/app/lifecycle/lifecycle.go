@@ -0,0 +1,214 @@
+// File: ./blockfreight/lib/app/lifecycle/lifecycle.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package lifecycle defines the lifecycle of a Bill of Lading: its states and the transitions between them which
+// each party may make.
+package lifecycle
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+)
+
+// State of a Bill of Lading.
+type State string
+
+// States of the lifecycle, in order. Surrendered, Accomplished and Void are final.
+const (
+	Draft          State = "draft"            // Created, not issued yet.
+	Issued         State = "issued"           // Issued by the carrier.
+	ShippedOnBoard State = "shipped-on-board" // The cargo is loaded on the vessel.
+	InTransit      State = "in-transit"       // The vessel left the port of loading.
+	Discharged     State = "discharged"       // The cargo is unloaded at the port of discharge.
+	Surrendered    State = "surrendered"      // The bill is surrendered to the carrier.
+	Accomplished   State = "accomplished"     // The cargo is delivered against the bill.
+	Void           State = "void"             // Cancelled before shipment.
+)
+
+// Role of the parties which may make a transition.
+type Role uint8
+
+// Roles of the parties of a Bill of Lading.
+const (
+	RoleIssuer Role = 1 // The party which created the BF_TX.
+	RoleHolder Role = 2 // The current holder of the bill, see package title.
+)
+
+// Transition is a change of state and the role of the parties which may make it.
+type Transition struct {
+	From State
+	To   State
+	Role Role
+}
+
+var (
+	// ErrUnknownState is returned when a state name is not part of the lifecycle.
+	ErrUnknownState = errors.New("Unknown Bill of Lading state.")
+	// ErrInvalidTransition is returned when the lifecycle has no transition between two states.
+	ErrInvalidTransition = errors.New("Invalid Bill of Lading state transition.")
+)
+
+// transitions lists every transition of the lifecycle.
+var transitions = []Transition{
+	{Draft, Issued, RoleIssuer},
+	{Draft, Void, RoleIssuer},
+	{Issued, ShippedOnBoard, RoleIssuer},
+	{Issued, Void, RoleIssuer},
+	{ShippedOnBoard, InTransit, RoleIssuer},
+	{InTransit, Discharged, RoleIssuer},
+	{Discharged, Surrendered, RoleHolder},
+	{Discharged, Accomplished, RoleIssuer},
+}
+
+// States returns every state of the lifecycle, in order.
+func States() []State {
+	return []State{Draft, Issued, ShippedOnBoard, InTransit, Discharged, Surrendered, Accomplished, Void}
+}
+
+// Parse returns the state of a name.
+func Parse(name string) (State, error) {
+	for _, state := range States() {
+		if string(state) == name {
+			return state, nil
+		}
+	}
+	return "", ErrUnknownState
+}
+
+// Find returns the transition between two states.
+func Find(from, to State) (Transition, error) {
+	for _, transition := range transitions {
+		if transition.From == from && transition.To == to {
+			return transition, nil
+		}
+	}
+	return Transition{}, ErrInvalidTransition
+}
+
+// Next returns the states which can follow a state, none for the final states.
+func Next(from State) []State {
+	var next []State
+	for _, transition := range transitions {
+		if transition.From == from {
+			next = append(next, transition.To)
+		}
+	}
+	return next
+}
+
+// Request is the payload of a transition transaction: the BF_TX and its new state.
+type Request struct {
+	Id    string `json:"id"`
+	State State  `json:"state"`
+}
+
+// Entry is a transition made on a BF_TX: by the hex public key of a party, at a block height.
+type Entry struct {
+	From   State  `json:"from,omitempty"` // Empty for the creation.
+	To     State  `json:"to"`
+	By     string `json:"by"`
+	Height uint64 `json:"height"`
+}
+
+// Record is the lifecycle of a BF_TX: its current state and every transition made on it.
+type Record struct {
+	State   State   `json:"state"`
+	History []Entry `json:"history"`
+}
+
+// NewRecord returns the record of a BF_TX created by a party at a height, in state Draft.
+func NewRecord(by string, height uint64) Record {
+	return Record{State: Draft, History: []Entry{{To: Draft, By: by, Height: height}}}
+}
+
+// Apply records a transition made by a party at a height.
+func (record *Record) Apply(transition Transition, by string, height uint64) {
+	record.History = append(record.History, Entry{From: transition.From, To: transition.To, By: by, Height: height})
+	record.State = transition.To
+}
+
+// Encode returns the JSON encoding of the record.
+func (record Record) Encode() []byte {
+	buf, _ := json.Marshal(record)
+	return buf
+}
+
+// DecodeRecord parses the JSON encoding of a record.
+func DecodeRecord(buf []byte) (Record, error) {
+	var record Record
+	err := json.Unmarshal(buf, &record)
+	return record, err
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
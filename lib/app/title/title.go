@@ -0,0 +1,173 @@
+// File: ./blockfreight/lib/app/title/title.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package title defines the title of a negotiable Bill of Lading: its holder and the chain of endorsements which
+// passed it from holder to holder.
+package title
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+)
+
+// Kind of a transfer of title.
+type Kind string
+
+// Kinds of transfer. The parties are named by their hex encoded public key.
+const (
+	KindEndorse   Kind = "endorse"   // Endorse the bill to a named party.
+	KindBlank     Kind = "blank"     // Endorse the bill in blank and deliver it to a bearer.
+	KindSurrender Kind = "surrender" // Surrender the bill to the carrier, which ends the transfers.
+)
+
+var (
+	// ErrUnknownKind is returned when a transfer kind is not supported.
+	ErrUnknownKind = errors.New("Unknown transfer kind.")
+	// ErrNoEndorsee is returned when an endorsement does not name the party which receives the bill.
+	ErrNoEndorsee = errors.New("The transfer does not name the party which receives the bill.")
+	// ErrSurrendered is returned when the bill has been surrendered already.
+	ErrSurrendered = errors.New("The bill has been surrendered to the carrier.")
+)
+
+// Request is the payload of a transfer transaction. To is empty for a surrender.
+type Request struct {
+	Id   string `json:"id"`
+	Kind Kind   `json:"kind"`
+	To   string `json:"to,omitempty"`
+}
+
+// Endorsement is a transfer made on a BF_TX, at a block height.
+type Endorsement struct {
+	Kind   Kind   `json:"kind"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Height uint64 `json:"height"`
+}
+
+// Record is the title of a BF_TX: its current holder and every transfer made on it.
+type Record struct {
+	Holder       string        `json:"holder"`
+	Bearer       bool          `json:"bearer,omitempty"` // Endorsed in blank, the holder is the bearer.
+	Surrendered  bool          `json:"surrendered,omitempty"`
+	Endorsements []Endorsement `json:"endorsements"`
+}
+
+// NewRecord returns the title of a BF_TX issued by a party, which holds it first.
+func NewRecord(issuer string) Record {
+	return Record{Holder: issuer, Endorsements: []Endorsement{}}
+}
+
+// Check checks a transfer request against the title.
+func (record Record) Check(req Request) error {
+	if record.Surrendered {
+		return ErrSurrendered
+	}
+	switch req.Kind {
+	case KindEndorse, KindBlank:
+		if req.To == "" {
+			return ErrNoEndorsee
+		}
+	case KindSurrender:
+	default:
+		return ErrUnknownKind
+	}
+	return nil
+}
+
+// Apply records a checked transfer made at a height. A surrender returns the bill to the carrier.
+func (record *Record) Apply(req Request, carrier string, height uint64) {
+	to := req.To
+	if req.Kind == KindSurrender {
+		to = carrier
+		record.Surrendered = true
+	}
+	record.Endorsements = append(record.Endorsements, Endorsement{Kind: req.Kind, From: record.Holder, To: to, Height: height})
+	record.Holder = to
+	record.Bearer = req.Kind == KindBlank
+}
+
+// Encode returns the JSON encoding of the record.
+func (record Record) Encode() []byte {
+	buf, _ := json.Marshal(record)
+	return buf
+}
+
+// DecodeRecord parses the JSON encoding of a record.
+func DecodeRecord(buf []byte) (Record, error) {
+	var record Record
+	err := json.Unmarshal(buf, &record)
+	return record, err
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,245 @@
+// File: ./blockfreight/lib/validator/result.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package validator
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"fmt" // Implements formatted I/O with functions analogous to C's printf and scanf.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/pkg/i18n"       // Provides the message catalog which localizes the messages.
+	"github.com/blockfreight/go-bftx/lib/pkg/jsonschema" // Provides a JSON Schema validator for JSON documents.
+)
+
+// Severity of a validation issue.
+type Severity string
+
+// Severities of the validation issues. Only errors make a BF_TX invalid.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Machine-readable codes of the validation issues.
+const (
+	CodeRequired     = "REQUIRED"       // A mandatory value is missing or empty.
+	CodeInvalidType  = "INVALID_TYPE"   // The value has the wrong JSON type.
+	CodeInvalidValue = "INVALID_VALUE"  // The value is not one of the allowed values.
+	CodeFormat       = "INVALID_FORMAT" // The value does not have the expected format.
+	CodeOutOfRange   = "OUT_OF_RANGE"   // The number or length is out of the allowed range.
+	CodeUnknownField = "UNKNOWN_FIELD"  // The field is not part of the BF_TX.
+	CodeConstraint   = "CONSTRAINT"     // A constraint involving several fields does not hold.
+	CodeWeakCrypto   = "WEAK_CRYPTO"    // The BF_TX is signed with a weak algorithm.
+)
+
+// Issue struct
+type Issue struct {
+	Path     string   `json:"path"` // JSON pointer (RFC 6901) of the field, e.g. /Properties/BolNum.
+	Rule     string   `json:"rule"` // Name of the rule which failed.
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+
+	key    string        // Catalog key of the message.
+	params []interface{} // Arguments of the message.
+}
+
+// newIssue returns an issue whose message is the catalog message of key, in the default locale.
+func newIssue(path, rule, code string, severity Severity, key string, params ...interface{}) Issue {
+	return Issue{
+		Path:     path,
+		Rule:     rule,
+		Code:     code,
+		Severity: severity,
+		Message:  i18n.Default().T(key, params...),
+		key:      key,
+		params:   params,
+	}
+}
+
+// String returns the issue as "<severity> <code> <path>: <message>".
+func (i Issue) String() string {
+	return i.Format(i18n.Default())
+}
+
+// ValidationResult struct
+type ValidationResult struct {
+	Valid  bool    `json:"valid"`
+	Schema string  `json:"schema,omitempty"` // $id of the JSON Schema, when the raw JSON was validated.
+	Issues []Issue `json:"issues"`
+
+	catalog *i18n.Catalog
+}
+
+// Localize returns a copy of the result with its messages in the locale of catalog.
+func (r ValidationResult) Localize(catalog *i18n.Catalog) ValidationResult {
+	localized := r
+	localized.catalog = catalog
+	localized.Issues = make([]Issue, len(r.Issues))
+	for i, issue := range r.Issues {
+		if issue.key != "" {
+			issue.Message = catalog.T(issue.key, issue.params...)
+		}
+		localized.Issues[i] = issue
+	}
+	return localized
+}
+
+// Format returns the issue as "<severity> <code> <path>: <message>", with the severity in the locale of catalog.
+func (i Issue) Format(catalog *i18n.Catalog) string {
+	path := i.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s %s %s: %s", catalog.T("validator.severity."+string(i.Severity)), i.Code, path, i.Message)
+}
+
+// NewValidationResult returns a valid result without issues.
+func NewValidationResult() ValidationResult {
+	return ValidationResult{Valid: true, Issues: []Issue{}}
+}
+
+// Add appends an issue to the result; an error makes the result invalid.
+func (r *ValidationResult) Add(issue Issue) {
+	if issue.Severity == SeverityError {
+		r.Valid = false
+	}
+	r.Issues = append(r.Issues, issue)
+}
+
+// Merge appends the issues of other to the result.
+func (r *ValidationResult) Merge(other ValidationResult) {
+	for _, issue := range other.Issues {
+		r.Add(issue)
+	}
+	if r.Schema == "" {
+		r.Schema = other.Schema
+	}
+}
+
+// Errors returns the issues with error severity.
+func (r ValidationResult) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the issues with warning severity.
+func (r ValidationResult) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+// Err returns nil when the result is valid, or an error summarizing the first error otherwise.
+func (r ValidationResult) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	catalog := r.catalog
+	if catalog == nil {
+		catalog = i18n.Default()
+	}
+	if len(errs) == 1 {
+		return catalog.Error("validator.invalid", errs[0].Format(catalog))
+	}
+	return catalog.Error("validator.invalid-more", errs[0].Format(catalog), len(errs)-1)
+}
+
+func (r ValidationResult) filter(severity Severity) []Issue {
+	issues := []Issue{}
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// schemaCodes maps the JSON Schema keywords to issue codes.
+var schemaCodes = map[string]string{
+	"required":             CodeRequired,
+	"type":                 CodeInvalidType,
+	"enum":                 CodeInvalidValue,
+	"const":                CodeInvalidValue,
+	"false":                CodeUnknownField,
+	"additionalProperties": CodeUnknownField,
+	"pattern":              CodeFormat,
+	"format":               CodeFormat,
+	"propertyNames":        CodeFormat,
+	"minimum":              CodeOutOfRange,
+	"maximum":              CodeOutOfRange,
+	"exclusiveMinimum":     CodeOutOfRange,
+	"exclusiveMaximum":     CodeOutOfRange,
+	"multipleOf":           CodeOutOfRange,
+	"minLength":            CodeOutOfRange,
+	"maxLength":            CodeOutOfRange,
+	"minItems":             CodeOutOfRange,
+	"maxItems":             CodeOutOfRange,
+	"minProperties":        CodeOutOfRange,
+	"maxProperties":        CodeOutOfRange,
+}
+
+// fromViolation converts a JSON Schema violation into an error issue.
+func fromViolation(violation jsonschema.Violation) Issue {
+	code, ok := schemaCodes[violation.Keyword]
+	if !ok {
+		code = CodeConstraint
+	}
+	key := "schema." + violation.Keyword
+	// An empty mandatory string is reported as missing
+	if violation.Keyword == "minLength" && len(violation.Params) == 0 {
+		code = CodeRequired
+		key = "schema.empty"
+	}
+	issue := newIssue(violation.Pointer, "schema."+violation.Keyword, code, SeverityError, key, violation.Params...)
+	if issue.Message == key {
+		// Keyword without catalog message
+		issue.Message = violation.Message
+		issue.key = ""
+	}
+	return issue
+}
@@ -49,146 +49,269 @@ import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"errors"  // Implements functions to manipulate errors.
-	"reflect" // Implements run-time reflection, allowing a program to manipulate objects with arbitrary types.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"os"            // Provides a platform-independent interface to operating system functionality.
+	"path/filepath" // Implements utility routines for manipulating filename paths.
+	"regexp"        // Implements regular expression search.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+	"time"          // Provides functionality for measuring and displaying time.
 
 	// ======================
 	// Blockfreight™ packages
 	// ======================
-	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"      // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"     // Provides useful functions to sign BF_TX.
+	"github.com/blockfreight/go-bftx/lib/pkg/jsonschema" // Provides a JSON Schema validator for JSON documents.
 )
 
-// ValidateBFTX is a function that receives the BF_TX and return the proper message according with the result of ValidateFields function.
-func ValidateBFTX(bftx bf_tx.BF_TX) (string, error) {
-	var espErr error
+// DefaultSchema is the path of the JSON Schema used to validate BF_TX documents when none is configured, relative
+// to the folder of the executable or, failing that, to the working directory.
+const DefaultSchema = "./examples/bf_tx_schema_pub_var_rfc2.json"
 
-	valid, err := ValidateFields(bftx)
-	if valid {
-		return "Success! [OK]", nil
-	}
-
-	if err != "" {
-		espErr = errors.New(`
-    Specific Error [01]:
-    ` + err)
-	}
-	return `
-    Blockfreight, Inc. © 2017. Open Source (MIT) License.
-
-    Error [01]:
-
-    Invalid structure in JSON provided. JSON 结构无效.
-    Struttura JSON non valido. هيكل JSON صالح. 無効なJSON構造.
-    Estructura inválida en el JSON dado.
-
-    support: support@blockfreight.com`, espErr
+// SchemaPath returns the JSON Schema to validate BF_TX documents with: the configured path when it is set, otherwise
+// DefaultSchema next to the executable when it is there, otherwise DefaultSchema in the working directory.
+func SchemaPath(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	if executable, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(executable), DefaultSchema)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return DefaultSchema
 }
 
-// ValidateFields is a function that receives the BF_TX, validates every field in the BF_TX and return true or false, and a message if some field is wrong.
-func ValidateFields(bftx bf_tx.BF_TX) (bool, string) {
-	if reflect.TypeOf(bftx.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Type is not a string."
-	}
-	if reflect.TypeOf(bftx.Properties.Shipper.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.Shipper.Type is not a string."
-	}
-	if (reflect.TypeOf(bftx.Properties.BolNum.Type) != reflect.TypeOf(1)) || bftx.Properties.BolNum.Type == 0 {
-		return false, "bftx.Properties.BolNum.Type is not a number."
-	}
-	if (reflect.TypeOf(bftx.Properties.RefNum.Type) != reflect.TypeOf(1)) || bftx.Properties.RefNum.Type == 0 {
-		return false, "bftx.Properties.RefNum.Type is not a number."
-	}
-	if reflect.TypeOf(bftx.Properties.Consignee.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.Consignee.Type is not a string."
-	}
-	if (reflect.TypeOf(bftx.Properties.Vessel.Type) != reflect.TypeOf(1)) || bftx.Properties.Vessel.Type == 0 {
-		return false, "bftx.Properties.Vessel.Type is not a number."
-	}
-	if (reflect.TypeOf(bftx.Properties.PortOfLoading.Type) != reflect.TypeOf(1)) || bftx.Properties.PortOfLoading.Type == 0 {
-		return false, "bftx.Properties.PortOfLoading.Type is not a number."
-	}
-	if (reflect.TypeOf(bftx.Properties.PortOfDischarge.Type) != reflect.TypeOf(1)) || bftx.Properties.PortOfDischarge.Type == 0 {
-		return false, "bftx.Properties.PortOfDischarge.Type is not a number."
-	}
-	if reflect.TypeOf(bftx.Properties.NotifyAddress.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.NotifyAddress.Type is not a string."
+// ValidateBFTX is a function that receives the BF_TX and returns every issue found in its fields.
+func ValidateBFTX(bftx bf_tx.BF_TX) ValidationResult {
+	v := &fieldValidator{result: NewValidationResult()}
+	prop := bftx.Properties
+
+	if bftx.Type != "object" {
+		v.fail("/Type", "type", CodeInvalidValue, "validator.type")
 	}
-	if reflect.TypeOf(bftx.Properties.DescOfGoods.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.DescOfGoods.Type is not a string."
+	v.required("/Properties/BolNum", prop.BolNum)
+	v.required("/Properties/Shipper/Name", prop.Shipper.Name)
+	v.party("/Properties/Shipper", prop.Shipper)
+	v.party("/Properties/Consignee", prop.Consignee)
+	v.party("/Properties/NotifyParty", prop.NotifyParty)
+	if prop.Consignee.Name == "" {
+		v.warn("/Properties/Consignee/Name", "consignee", CodeRequired, "validator.consignee")
 	}
-	if (reflect.TypeOf(bftx.Properties.GrossWeight.Type) != reflect.TypeOf(1)) || bftx.Properties.GrossWeight.Type == 0 {
-		return false, "bftx.Properties.GrossWeight.Type is not a number."
+
+	if prop.Vessel.Name == "" && prop.Vessel.IMONumber == "" {
+		v.fail("/Properties/Vessel", "vessel", CodeRequired, "validator.vessel")
 	}
-	if (reflect.TypeOf(bftx.Properties.FreightPayableAmt.Type) != reflect.TypeOf(1)) || bftx.Properties.FreightPayableAmt.Type == 0 {
-		return false, "bftx.Properties.FreightPayableAmt.Type is not a number."
+	v.pattern("/Properties/Vessel/IMONumber", "imo-number", prop.Vessel.IMONumber, imoRegexp, "validator.imo-number")
+	v.port("/Properties/PortOfLoading", prop.PortOfLoading)
+	v.port("/Properties/PortOfDischarge", prop.PortOfDischarge)
+
+	if len(prop.Cargo) == 0 {
+		v.fail("/Properties/Cargo", "cargo", CodeRequired, "validator.cargo")
 	}
-	if (reflect.TypeOf(bftx.Properties.FreightAdvAmt.Type) != reflect.TypeOf(1)) || bftx.Properties.FreightAdvAmt.Type == 0 {
-		return false, "bftx.Properties.FreightAdvAmt.Type is not a number."
+	for i, item := range prop.Cargo {
+		v.cargoItem(fmt.Sprintf("/Properties/Cargo/%d", i), item)
 	}
-	if reflect.TypeOf(bftx.Properties.GeneralInstructions.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.GeneralInstructions.Type is not a string."
+	v.freight("/Properties/Freight", prop.Freight)
+
+	v.date("/Properties/DateShipped", prop.DateShipped)
+	v.date("/Properties/IssueDetails/DateOfIssue", prop.IssueDetails.DateOfIssue)
+	if isDate(prop.DateShipped) && isDate(prop.IssueDetails.DateOfIssue) && prop.IssueDetails.DateOfIssue < prop.DateShipped {
+		v.warn("/Properties/IssueDetails/DateOfIssue", "date-of-issue", CodeConstraint, "validator.date-of-issue")
 	}
-	if (reflect.TypeOf(bftx.Properties.DateShipped.Type) != reflect.TypeOf(1)) || bftx.Properties.DateShipped.Type == 0 {
-		return false, "bftx.Properties.DateShipped.Type is not a number."
+	if prop.NumBol <= 0 {
+		v.fail("/Properties/NumBol", "num-bol", CodeOutOfRange, "validator.num-bol")
 	}
-	if reflect.TypeOf(bftx.Properties.DateShipped.Format) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.DateShipped.Format is not a date format."
+
+	if bftx.Signature != "" && crypto.WeakSignature(bftx) {
+		v.warn("/SignAlgorithm", "sign-algorithm", CodeWeakCrypto, "validator.sign-algorithm")
 	}
-	if reflect.TypeOf(bftx.Properties.IssueDetails.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.IssueDetails.Type is not a string."
+	return v.result
+}
+
+// ValidateFields is a function that receives the BF_TX, validates every field in the BF_TX and return true or false, and the first error if some field is wrong.
+func ValidateFields(bftx bf_tx.BF_TX) (bool, string) {
+	result := ValidateBFTX(bftx)
+	if result.Valid {
+		return true, ""
 	}
-	if reflect.TypeOf(bftx.Properties.IssueDetails.Properties.PlaceOfIssue.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.IssueDetails.Properties.PlaceOfIssue.Type is not a string."
+	return false, result.Errors()[0].String()
+}
+
+// ValidateJSON validates the raw JSON of a BF_TX against the JSON Schema stored in schemaPath, before it is unmarshalled.
+// It returns every violation as an error issue. Documents in the legacy placeholder format are converted first.
+func ValidateJSON(schemaPath string, data []byte) (ValidationResult, error) {
+	result := NewValidationResult()
+	schema, err := jsonschema.Load(schemaPath)
+	if err != nil {
+		return result, err
+	}
+	result.Schema = schema.ID()
+	if bf_tx.IsLegacy(data) {
+		bftx, err := bf_tx.ConvertLegacy(data)
+		if err != nil {
+			return result, err
+		}
+		if data, err = json.Marshal(bftx); err != nil {
+			return result, err
+		}
+	}
+	violations, err := schema.Validate(data)
+	if err != nil {
+		return result, err
+	}
+	for _, violation := range violations {
+		result.Add(fromViolation(violation))
+	}
+	return result, nil
+}
+
+// ValidateDocument validates the raw JSON of a BF_TX against the schema and, when it matches, its fields.
+func ValidateDocument(schemaPath string, data []byte) (ValidationResult, error) {
+	result, err := ValidateJSON(schemaPath, data)
+	if err != nil || !result.Valid {
+		return result, err
+	}
+	var bftx bf_tx.BF_TX
+	if bf_tx.IsLegacy(data) {
+		bftx, err = bf_tx.ConvertLegacy(data)
+	} else {
+		bftx, err = bf_tx.DecodeBFTX(data)
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Merge(ValidateBFTX(bftx))
+	return result, nil
+}
+
+var (
+	imoRegexp     = regexp.MustCompile(`^[0-9]{7}$`)
+	locodeRegexp  = regexp.MustCompile(`^[A-Z]{2}[A-Z2-9]{3}$`)
+	countryRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+	hsCodeRegexp  = regexp.MustCompile(`^[0-9]{6,10}$`)
+	currRegexp    = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+// Units accepted in measures (UN/ECE Recommendation 20 codes).
+var (
+	weightUnits = map[string]bool{"KGM": true, "TNE": true, "LBR": true}
+	volumeUnits = map[string]bool{"MTQ": true, "FTQ": true, "LTR": true}
+	freightTerm = map[string]bool{"": true, "PREPAID": true, "COLLECT": true}
+)
+
+// fieldValidator collects the issues found in the fields of a BF_TX.
+type fieldValidator struct {
+	result ValidationResult
+}
+
+// fail adds an error whose message is the catalog message of key.
+func (v *fieldValidator) fail(path, rule, code, key string, params ...interface{}) {
+	v.result.Add(newIssue(path, rule, code, SeverityError, key, params...))
+}
+
+// warn adds a warning whose message is the catalog message of key.
+func (v *fieldValidator) warn(path, rule, code, key string, params ...interface{}) {
+	v.result.Add(newIssue(path, rule, code, SeverityWarning, key, params...))
+}
+
+// required fails when value is empty.
+func (v *fieldValidator) required(path, value string) {
+	if value == "" {
+		v.fail(path, "required", CodeRequired, "validator.required")
 	}
-	if (reflect.TypeOf(bftx.Properties.IssueDetails.Properties.DateOfIssue.Type) != reflect.TypeOf(1)) || bftx.Properties.IssueDetails.Properties.DateOfIssue.Type == 0 {
-		return false, "bftx.Properties.IssueDetails.Properties.DateOfIssue.Type is not a number."
+}
+
+// pattern fails when value is set and does not match re.
+func (v *fieldValidator) pattern(path, rule, value string, re *regexp.Regexp, key string) {
+	if value != "" && !re.MatchString(value) {
+		v.fail(path, rule, CodeFormat, key)
 	}
-	if reflect.TypeOf(bftx.Properties.IssueDetails.Properties.DateOfIssue.Format) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.IssueDetails.Properties.PlaceOfIssue.Format is not a date format."
+}
+
+// date fails when value is set and is not an ISO 8601 date.
+func (v *fieldValidator) date(path, value string) {
+	if value != "" && !isDate(value) {
+		v.fail(path, "date", CodeFormat, "validator.date")
 	}
-	if (reflect.TypeOf(bftx.Properties.NumBol.Type) != reflect.TypeOf(1)) || bftx.Properties.NumBol.Type == 0 {
-		return false, "bftx.Properties.NumBol.Type is not a number."
+}
+
+// party validates the optional attributes of a party.
+func (v *fieldValidator) party(path string, party bf_tx.Party) {
+	v.pattern(path+"/Address/Country", "country", party.Address.Country, countryRegexp, "validator.country")
+	for i, contact := range party.Contacts {
+		if contact.Email != "" && !strings.Contains(contact.Email, "@") {
+			v.fail(fmt.Sprintf("%s/Contacts/%d/Email", path, i), "email", CodeFormat, "validator.email")
+		}
 	}
-	if reflect.TypeOf(bftx.Properties.MasterInfo.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.MasterInfo.Type is not a string."
+}
+
+// port validates a port, which needs a UN/LOCODE or a name.
+func (v *fieldValidator) port(path string, port bf_tx.Port) {
+	if port.Locode == "" && port.Name == "" {
+		v.fail(path, "port", CodeRequired, "validator.port")
 	}
-	if reflect.TypeOf(bftx.Properties.MasterInfo.Properties.FirstName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.MasterInfo.Properties.FirstName.Type is not a string."
+	v.pattern(path+"/Locode", "locode", port.Locode, locodeRegexp, "validator.locode")
+	if port.Locode == "" && port.Name != "" {
+		v.warn(path+"/Locode", "locode", CodeRequired, "validator.locode-missing")
 	}
-	if reflect.TypeOf(bftx.Properties.MasterInfo.Properties.LastName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.MasterInfo.Properties.LastName.Type is not a string."
+}
+
+// cargoItem validates a line of the description of goods.
+func (v *fieldValidator) cargoItem(path string, item bf_tx.CargoItem) {
+	v.required(path+"/Description", item.Description)
+	if item.Packages < 0 {
+		v.fail(path+"/Packages", "packages", CodeOutOfRange, "validator.packages")
 	}
-	if reflect.TypeOf(bftx.Properties.MasterInfo.Properties.Sig.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.MasterInfo.Properties.Sig.Type is not a string."
+	v.pattern(path+"/HSCode", "hs-code", item.HSCode, hsCodeRegexp, "validator.hs-code")
+	if item.HSCode == "" {
+		v.warn(path+"/HSCode", "hs-code", CodeRequired, "validator.hs-code-missing")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForMaster.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForMaster.Type is not a string."
+	if item.GrossWeight.Value <= 0 {
+		v.fail(path+"/GrossWeight/Value", "gross-weight", CodeOutOfRange, "validator.gross-weight")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForMaster.Properties.FirstName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForMaster.Properties.FirstName.Type is not a string."
+	if !weightUnits[item.GrossWeight.Unit] {
+		v.fail(path+"/GrossWeight/Unit", "weight-unit", CodeInvalidValue, "validator.weight-unit")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForMaster.Properties.LastName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForMaster.Properties.LastName.Type is not a string."
+	if item.Volume.Value < 0 {
+		v.fail(path+"/Volume/Value", "volume", CodeOutOfRange, "validator.volume")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForMaster.Properties.Sig.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForMaster.Properties.Sig.Type is not a string."
+	if item.Volume.Value > 0 && !volumeUnits[item.Volume.Unit] {
+		v.fail(path+"/Volume/Unit", "volume-unit", CodeInvalidValue, "validator.volume-unit")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForOwner.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForOwner.Type is not a string."
+}
+
+// freight validates the freight terms and charges.
+func (v *fieldValidator) freight(path string, freight bf_tx.Freight) {
+	if !freightTerm[freight.Terms] {
+		v.fail(path+"/Terms", "freight-terms", CodeInvalidValue, "validator.freight-terms")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForOwner.Properties.FirstName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForOwner.Properties.FirstName.Type is not a string."
+	v.pattern(path+"/Currency", "currency", freight.Currency, currRegexp, "validator.currency")
+	if freight.PayableAmount < 0 {
+		v.fail(path+"/PayableAmount", "amount", CodeOutOfRange, "validator.amount", "PayableAmount")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForOwner.Properties.LastName.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForOwner.Properties.LastName.Type is not a string."
+	if freight.AdvanceAmount < 0 {
+		v.fail(path+"/AdvanceAmount", "amount", CodeOutOfRange, "validator.amount", "AdvanceAmount")
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForOwner.Properties.Sig.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForOwner.Properties.Sig.Type is not a string."
+	for i, charge := range freight.Charges {
+		if charge.Amount < 0 {
+			v.fail(fmt.Sprintf("%s/Charges/%d/Amount", path, i), "amount", CodeOutOfRange, "validator.amount", "Amount")
+		}
+		if !freightTerm[charge.Terms] {
+			v.fail(fmt.Sprintf("%s/Charges/%d/Terms", path, i), "freight-terms", CodeInvalidValue, "validator.freight-terms")
+		}
 	}
-	if reflect.TypeOf(bftx.Properties.AgentForOwner.Properties.ConditionsForCarriage.Type) != reflect.TypeOf("s") {
-		return false, "bftx.Properties.AgentForOwner.Properties.ConditionsForCarriage.Type is not a string."
+	if freight.Currency == "" && (freight.PayableAmount > 0 || freight.AdvanceAmount > 0 || len(freight.Charges) > 0) {
+		v.warn(path+"/Currency", "currency", CodeRequired, "validator.currency-missing")
 	}
-	return true, ""
+}
+
+// isDate reports whether value is an ISO 8601 date (YYYY-MM-DD).
+func isDate(value string) bool {
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
 }
 
 // =================================================
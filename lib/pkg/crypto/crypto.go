@@ -51,12 +51,17 @@ import (
 	// =======================
 	"crypto/ecdsa"    // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
 	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
-	"crypto/md5"      // Implements the MD5 hash algorithm as defined in RFC 1321.
 	"crypto/rand"     // Implements a cryptographically secure pseudorandom number generator.
+	"crypto/sha256"   // Implements the SHA256 Algorithm for Hash.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"errors"          // Implements functions to manipulate errors.
 	"hash"            // Provides interfaces for hash functions.
-	"io"              // Provides basic interfaces to I/O primitives.
 	"math/big"        // Implements arbitrary-precision arithmetic (big numbers).
-	"strconv"         // Implements conversions to and from string representations of basic data types.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"golang.org/x/crypto/sha3" // Implements the SHA-3 fixed-output-length hash functions.
 
 	// ======================
 	// Blockfreight™ packages
@@ -64,57 +69,185 @@ import (
 	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
 )
 
-// SignBFTX has the whole process of signing each BF_TX.
-func SignBFTX(bftx bf_tx.BF_TX) (bf_tx.BF_TX, error) {
+// coordinateSize is the size in bytes of each half (r and s) of a P-256 signature.
+const coordinateSize = 32
 
-	content, err := bf_tx.BFTXContent(bftx)
-	if err != nil {
-		return bftx, err
-	}
+// Signature algorithms. The identifier is stored in the BF_TX next to its signature.
+const (
+	AlgorithmSHA256 = "ECDSA-P256-SHA256"
+	AlgorithmSHA3   = "ECDSA-P256-SHA3-256"
+	AlgorithmMD5    = "ECDSA-P256-MD5" // Legacy, signatures of the first releases, only verified by VerifyLegacy.
+)
+
+// DefaultAlgorithm is the algorithm used by SignBFTX.
+const DefaultAlgorithm = AlgorithmSHA256
+
+var (
+	// ErrMissingSignature is returned when the BF_TX has no signature.
+	ErrMissingSignature = errors.New("BF_TX is not signed.")
+	// ErrInvalidPublicKey is returned when the public key embedded in the BF_TX is not a P-256 point.
+	ErrInvalidPublicKey = errors.New("BF_TX public key is not valid.")
+	// ErrInvalidSignature is returned when the BF_TX signature is malformed or does not verify.
+	ErrInvalidSignature = errors.New("BF_TX signature is not valid.")
+	// ErrUnknownAlgorithm is returned when the signature algorithm is not supported.
+	ErrUnknownAlgorithm = errors.New("BF_TX signature algorithm is not supported.")
+)
 
-	pubkeyCurve := elliptic.P256() //see http://golang.org/pkg/crypto/elliptic/#P256
+// SignBFTX has the whole process of signing each BF_TX with the given private key and the default algorithm.
+// Only the public key and the signature are embedded in the BF_TX.
+func SignBFTX(bftx bf_tx.BF_TX, privatekey *ecdsa.PrivateKey) (bf_tx.BF_TX, error) {
+	return SignBFTXWithAlgorithm(bftx, privatekey, DefaultAlgorithm)
+}
 
-	privatekey := new(ecdsa.PrivateKey)
-	privatekey, err = ecdsa.GenerateKey(pubkeyCurve, rand.Reader) // this generates a public & private key pair
+// SignBFTXWithAlgorithm signs the BF_TX hashing its content with the given algorithm.
+func SignBFTXWithAlgorithm(bftx bf_tx.BF_TX, privatekey *ecdsa.PrivateKey, algorithm string) (bf_tx.BF_TX, error) {
+	content, err := bf_tx.CanonicalContent(bftx)
 	if err != nil {
 		return bftx, err
 	}
+
 	pubkey := privatekey.PublicKey
 
 	// Sign ecdsa style
-	var h hash.Hash
-	h = md5.New()
+	signhash, err := hashContent(content, algorithm)
+	if err != nil {
+		return bftx, err
+	}
 	r := big.NewInt(0)
 	s := big.NewInt(0)
 
-	io.WriteString(h, content)
-	signhash := h.Sum(nil)
-
 	r, s, err = ecdsa.Sign(rand.Reader, privatekey, signhash)
 	if err != nil {
 		return bftx, err
 	}
 
-	signature := r.Bytes()
-	signature = append(signature, s.Bytes()...)
-
-	sign := ""
-	for i, _ := range signature {
-		sign += strconv.Itoa(int(signature[i]))
-	}
-
 	// Verification
 	verifystatus := ecdsa.Verify(&pubkey, signhash, r, s)
 
-	//Set Private Key and Sign to BF_TX
-	bftx.PrivateKey = *privatekey
+	//Set Public Key and Sign to BF_TX
+	bftx.PublicKey = EncodePublicKey(&pubkey)
 	bftx.Signhash = signhash
-	bftx.Signature = sign
+	bftx.SignAlgorithm = algorithm
+	bftx.Signature = EncodeSignature(r, s)
 	bftx.Verified = verifystatus
 
 	return bftx, nil
 }
 
+// VerifySignature checks that the BF_TX is signed and that its signature verifies against the embedded public key.
+func VerifySignature(bftx bf_tx.BF_TX) error {
+	if bftx.Signature == "" {
+		return ErrMissingSignature
+	}
+	if SignatureAlgorithm(bftx) == AlgorithmMD5 {
+		return ErrUnverifiableSignature
+	}
+
+	pubkey, err := ParsePublicKey(bftx.PublicKey)
+	if err != nil {
+		return err
+	}
+	return VerifyBFTX(bftx, pubkey)
+}
+
+// VerifyBFTX checks that the BF_TX signature verifies against the given public key. Legacy MD5 signatures cover
+// the legacy record, which VerifyLegacy checks, so they cannot be verified on the BF_TX.
+func VerifyBFTX(bftx bf_tx.BF_TX, pubkey *ecdsa.PublicKey) error {
+	if bftx.Signature == "" {
+		return ErrMissingSignature
+	}
+	if SignatureAlgorithm(bftx) == AlgorithmMD5 {
+		return ErrUnverifiableSignature
+	}
+
+	r, s, err := DecodeSignature(bftx.Signature)
+	if err != nil {
+		return err
+	}
+
+	// The signed content is the Bill of Lading content only
+	content, err := bf_tx.CanonicalContent(bftx)
+	if err != nil {
+		return err
+	}
+
+	signhash, err := hashContent(content, SignatureAlgorithm(bftx))
+	if err != nil {
+		return err
+	}
+
+	if !ecdsa.Verify(pubkey, signhash, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// SignatureAlgorithm returns the algorithm of the BF_TX signature.
+// Signatures without algorithm identifier were made with MD5.
+func SignatureAlgorithm(bftx bf_tx.BF_TX) string {
+	if bftx.SignAlgorithm == "" {
+		return AlgorithmMD5
+	}
+	return bftx.SignAlgorithm
+}
+
+// WeakSignature reports whether the BF_TX signature relies on a legacy hash algorithm and should be renewed.
+func WeakSignature(bftx bf_tx.BF_TX) bool {
+	return bftx.Signature != "" && SignatureAlgorithm(bftx) == AlgorithmMD5
+}
+
+// EncodeSignature returns the signature format of BF_TX: the hex encoding of the fixed-width (32 bytes each) r || s.
+func EncodeSignature(r, s *big.Int) string {
+	signature := make([]byte, 2*coordinateSize)
+	r.FillBytes(signature[:coordinateSize])
+	s.FillBytes(signature[coordinateSize:])
+	return hex.EncodeToString(signature)
+}
+
+// DecodeSignature parses a signature produced by EncodeSignature.
+func DecodeSignature(sign string) (r, s *big.Int, err error) {
+	signature, err := hex.DecodeString(sign)
+	if err != nil || len(signature) != 2*coordinateSize {
+		return nil, nil, ErrInvalidSignature
+	}
+	r = new(big.Int).SetBytes(signature[:coordinateSize])
+	s = new(big.Int).SetBytes(signature[coordinateSize:])
+	return r, s, nil
+}
+
+// EncodePublicKey returns the hex encoding of the uncompressed P-256 point of the public key.
+func EncodePublicKey(pubkey *ecdsa.PublicKey) string {
+	return hex.EncodeToString(elliptic.Marshal(elliptic.P256(), pubkey.X, pubkey.Y))
+}
+
+// ParsePublicKey parses a hex encoded uncompressed P-256 public key.
+func ParsePublicKey(hexkey string) (*ecdsa.PublicKey, error) {
+	point, err := hex.DecodeString(hexkey)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+	x, y := elliptic.Unmarshal(elliptic.P256(), point)
+	if x == nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+// hashContent returns the hash of the content which is signed.
+func hashContent(content []byte, algorithm string) ([]byte, error) {
+	var h hash.Hash
+	switch algorithm {
+	case AlgorithmSHA256:
+		h = sha256.New()
+	case AlgorithmSHA3:
+		h = sha3.New256()
+	default:
+		return nil, ErrUnknownAlgorithm
+	}
+	h.Write(content)
+	return h.Sum(nil), nil
+}
+
 // =================================================
 // Blockfreight™ | The blockchain of global freight.
 // =================================================
@@ -0,0 +1,401 @@
+// File: ./blockfreight/lib/crypto/keystore.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package crypto
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"crypto/aes"      // Implements AES encryption (formerly Rijndael), as defined in U.S. FIPS 197.
+	"crypto/cipher"   // Implements standard block cipher modes that can be wrapped around low-level block cipher implementations.
+	"crypto/ecdsa"    // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
+	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
+	"crypto/rand"     // Implements a cryptographically secure pseudorandom number generator.
+	"encoding/hex"    // Implements hexadecimal encoding and decoding.
+	"encoding/json"   // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"          // Implements functions to manipulate errors.
+	"io/ioutil"       // Implements some I/O utility functions.
+	"math/big"        // Implements arbitrary-precision arithmetic (big numbers).
+	"os"              // Provides a platform-independent interface to operating system functionality.
+	"path/filepath"   // Implements utility routines for manipulating filename paths.
+	"regexp"          // Implements regular expression search.
+	"sort"            // Provides primitives for sorting slices and user-defined collections.
+	"strings"         // Implements simple functions to manipulate UTF-8 encoded strings.
+
+	// ====================
+	// Third-party packages
+	// ====================
+	"golang.org/x/crypto/scrypt" // Implements the scrypt key derivation function.
+)
+
+// keyFileVersion is the current version of the key file format.
+const keyFileVersion = 1
+
+// keyFileExt is the extension of every key file in the keystore.
+const keyFileExt = ".key"
+
+// Default scrypt parameters used to derive the encryption key from the passphrase.
+const (
+	ScryptN = 1 << 15
+	ScryptR = 8
+	ScryptP = 1
+)
+
+var (
+	// ErrKeyExists is returned when a key with the same name is already in the keystore.
+	ErrKeyExists = errors.New("Key already exists in the keystore.")
+	// ErrKeyNotFound is returned when the key is not in the keystore.
+	ErrKeyNotFound = errors.New("Key not found in the keystore.")
+	// ErrInvalidKeyName is returned when the key name cannot be used as a file name.
+	ErrInvalidKeyName = errors.New("Key name must only contain letters, digits, '-', '_' and '.'.")
+	// ErrWrongPassphrase is returned when the key cannot be decrypted with the passphrase.
+	ErrWrongPassphrase = errors.New("Wrong passphrase or corrupted key file.")
+)
+
+var keyNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Keystore stores named P-256 signing keys encrypted on disk with a passphrase (scrypt + AES-GCM).
+type Keystore struct {
+	dir     string
+	scryptN int
+}
+
+// KeyInfo describes a key of the keystore without exposing its private part.
+type KeyInfo struct {
+	Name      string
+	PublicKey string
+}
+
+// keyFile is the on-disk format of an encrypted key.
+type keyFile struct {
+	Version    int
+	Name       string
+	PublicKey  string
+	KDF        string
+	N          int
+	R          int
+	P          int
+	Salt       string
+	Nonce      string
+	Ciphertext string
+}
+
+// NewKeystore opens the keystore located in dir, creating the directory if needed.
+func NewKeystore(dir string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Keystore{dir: dir, scryptN: ScryptN}, nil
+}
+
+// SetScryptN changes the scrypt cost used for new keys. Lower values are only meant for tests.
+func (ks *Keystore) SetScryptN(n int) {
+	ks.scryptN = n
+}
+
+// Create generates a new key, stores it encrypted under name and returns its public key.
+func (ks *Keystore) Create(name, passphrase string) (*ecdsa.PublicKey, error) {
+	privatekey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Import(name, privatekey, passphrase); err != nil {
+		return nil, err
+	}
+	return &privatekey.PublicKey, nil
+}
+
+// ImportHex stores an existing key given as the hex encoding of its private scalar.
+func (ks *Keystore) ImportHex(name, hexkey, passphrase string) (*ecdsa.PublicKey, error) {
+	d, err := hex.DecodeString(strings.TrimSpace(hexkey))
+	if err != nil || len(d) != coordinateSize {
+		return nil, errors.New("Private key must be a 32 bytes hex string.")
+	}
+
+	curve := elliptic.P256()
+	privatekey := new(ecdsa.PrivateKey)
+	privatekey.Curve = curve
+	privatekey.D = new(big.Int).SetBytes(d)
+	if privatekey.D.Sign() == 0 || privatekey.D.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("Private key is out of range.")
+	}
+	privatekey.X, privatekey.Y = curve.ScalarBaseMult(d)
+
+	if err := ks.Import(name, privatekey, passphrase); err != nil {
+		return nil, err
+	}
+	return &privatekey.PublicKey, nil
+}
+
+// Import stores an existing key encrypted under name.
+func (ks *Keystore) Import(name string, privatekey *ecdsa.PrivateKey, passphrase string) error {
+	path, err := ks.path(name)
+	if err != nil {
+		return err
+	}
+	// Fails early before deriving the key, the file is created exclusively below
+	if _, err := os.Stat(path); err == nil {
+		return ErrKeyExists
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	kf := keyFile{
+		Version:   keyFileVersion,
+		Name:      name,
+		PublicKey: EncodePublicKey(&privatekey.PublicKey),
+		KDF:       "scrypt",
+		N:         ks.scryptN,
+		R:         ScryptR,
+		P:         ScryptP,
+		Salt:      hex.EncodeToString(salt),
+	}
+
+	aead, err := kf.cipher(passphrase)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	plaintext := make([]byte, coordinateSize)
+	privatekey.D.FillBytes(plaintext)
+
+	kf.Nonce = hex.EncodeToString(nonce)
+	kf.Ciphertext = hex.EncodeToString(aead.Seal(nil, nonce, plaintext, kf.additionalData()))
+
+	content, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// A key of the same name imported meanwhile is not overwritten
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if os.IsExist(err) {
+		return ErrKeyExists
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// Load decrypts the key stored under name.
+func (ks *Keystore) Load(name, passphrase string) (*ecdsa.PrivateKey, error) {
+	kf, err := ks.read(name)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := kf.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(kf.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	ciphertext, err := hex.DecodeString(kf.Ciphertext)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	d, err := aead.Open(nil, nonce, ciphertext, kf.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	curve := elliptic.P256()
+	privatekey := new(ecdsa.PrivateKey)
+	privatekey.Curve = curve
+	privatekey.D = new(big.Int).SetBytes(d)
+	privatekey.X, privatekey.Y = curve.ScalarBaseMult(d)
+	if EncodePublicKey(&privatekey.PublicKey) != kf.PublicKey {
+		return nil, ErrWrongPassphrase
+	}
+	return privatekey, nil
+}
+
+// List returns the keys of the keystore sorted by name.
+func (ks *Keystore) List() ([]KeyInfo, error) {
+	files, err := ioutil.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []KeyInfo{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != keyFileExt {
+			continue
+		}
+		kf, err := ks.read(strings.TrimSuffix(file.Name(), keyFileExt))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, KeyInfo{Name: kf.Name, PublicKey: kf.PublicKey})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// Find returns the name of the key whose public key is the given hex encoded public key.
+func (ks *Keystore) Find(publickey string) (string, error) {
+	keys, err := ks.List()
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if strings.EqualFold(key.PublicKey, publickey) {
+			return key.Name, nil
+		}
+	}
+	return "", ErrKeyNotFound
+}
+
+// Delete removes the key stored under name.
+func (ks *Keystore) Delete(name string) error {
+	path, err := ks.path(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return ErrKeyNotFound
+	}
+	return err
+}
+
+// path returns the file path of the key stored under name.
+func (ks *Keystore) path(name string) (string, error) {
+	if !keyNameRegexp.MatchString(name) {
+		return "", ErrInvalidKeyName
+	}
+	return filepath.Join(ks.dir, name+keyFileExt), nil
+}
+
+// read reads the key file stored under name.
+func (ks *Keystore) read(name string) (keyFile, error) {
+	var kf keyFile
+	path, err := ks.path(name)
+	if err != nil {
+		return kf, err
+	}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return kf, ErrKeyNotFound
+	}
+	if err != nil {
+		return kf, err
+	}
+	if err := json.Unmarshal(content, &kf); err != nil {
+		return kf, err
+	}
+	if kf.Version != keyFileVersion || kf.KDF != "scrypt" {
+		return kf, errors.New("Key file format of " + name + " is not supported.")
+	}
+	return kf, nil
+}
+
+// cipher derives the AES-GCM cipher of the key file from the passphrase.
+func (kf keyFile) cipher(passphrase string) (cipher.AEAD, error) {
+	salt, err := hex.DecodeString(kf.Salt)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, kf.N, kf.R, kf.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds the ciphertext to the key name and public key.
+func (kf keyFile) additionalData() []byte {
+	return []byte(kf.Name + ":" + kf.PublicKey)
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,296 @@
+// File: ./blockfreight/lib/pkg/crypto/legacy.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package crypto
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"           // Implements functions for the manipulation of byte slices.
+	"crypto/ecdsa"    // Implements the Elliptic Curve Digital Signature Algorithm, as defined in FIPS 186-3.
+	"crypto/elliptic" // Implements several standard elliptic curves over prime fields.
+	"crypto/md5"      // Implements the MD5 hash algorithm as defined in RFC 1321.
+	"encoding/json"   // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"          // Implements functions to manipulate errors.
+	"math/big"        // Implements arbitrary-precision arithmetic (big numbers).
+)
+
+// maxLegacyCandidates bounds the readings of a legacy signature which are tried.
+const maxLegacyCandidates = 4096
+
+// ErrUnverifiableSignature is returned when a legacy signature cannot be decoded: its hash matches the content
+// but nothing proves the signer made it.
+var ErrUnverifiableSignature = errors.New("BF_TX legacy signature cannot be decoded, the BF_TX is unverifiable.")
+
+// legacyKey mirrors the ecdsa.PrivateKey which the first releases stored in the BF_TX.
+type legacyKey struct {
+	X, Y, D *big.Int
+}
+
+// legacyUnsigned holds the values of the signature attributes when the first releases hashed a BF_TX.
+var legacyUnsigned = map[string]json.RawMessage{
+	"PrivateKey": json.RawMessage(`{"Curve":null,"X":null,"Y":null,"D":null}`),
+	"Signhash":   json.RawMessage(`null`),
+	"Signature":  json.RawMessage(`""`),
+	"Verified":   json.RawMessage(`false`),
+}
+
+// VerifyLegacy verifies a BF_TX record stored by the first releases, which signed the MD5 hash of the JSON of the
+// whole BF_TX, stored the private key next to it and wrote the signature bytes as concatenated decimals.
+// It returns the hex encoded public key of the stored private key. The hash is checked against the stored one;
+// the signature is checked when its decimals can be read back in a bounded number of ways, otherwise
+// ErrUnverifiableSignature is returned. Legacy signatures are weak even when they verify.
+func VerifyLegacy(data []byte) (string, error) {
+	var stored struct {
+		PrivateKey legacyKey
+		Signhash   []byte
+		Signature  string
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return "", err
+	}
+	if stored.Signature == "" {
+		return "", ErrMissingSignature
+	}
+
+	// The public key is derived from the stored private key, which has to match it
+	curve := elliptic.P256()
+	if stored.PrivateKey.D == nil || stored.PrivateKey.D.Sign() <= 0 || stored.PrivateKey.D.Cmp(curve.Params().N) >= 0 {
+		return "", ErrInvalidPublicKey
+	}
+	x, y := curve.ScalarBaseMult(stored.PrivateKey.D.Bytes())
+	if stored.PrivateKey.X != nil && (stored.PrivateKey.X.Cmp(x) != 0 || stored.PrivateKey.Y == nil || stored.PrivateKey.Y.Cmp(y) != 0) {
+		return "", ErrInvalidPublicKey
+	}
+	pubkey := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	encoded := EncodePublicKey(pubkey)
+
+	// The content changed after it was signed when no reading of it gives the stored hash
+	signhash, err := legacySignhash(data, stored.Signhash)
+	if err != nil {
+		return encoded, err
+	}
+
+	candidates, complete := legacySignatures(stored.Signature)
+	for _, candidate := range candidates {
+		if ecdsa.Verify(pubkey, signhash, candidate[0], candidate[1]) {
+			return encoded, nil
+		}
+	}
+	if complete {
+		return encoded, ErrInvalidSignature
+	}
+	return encoded, ErrUnverifiableSignature
+}
+
+// legacySignhash recomputes the MD5 hash of the legacy BF_TX as it was before its signature, and checks it against
+// the stored hash. Transmitted and Amendment may have changed after the signature, both values are tried.
+func legacySignhash(data []byte, stored []byte) ([]byte, error) {
+	keys, values, err := objectFields(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, transmitted := range []bool{false, true} {
+		for _, amended := range []bool{false, true} {
+			var buf bytes.Buffer
+			buf.WriteByte('{')
+			for i, key := range keys {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				name, _ := json.Marshal(key)
+				buf.Write(name)
+				buf.WriteByte(':')
+				value, reset := legacyUnsigned[key]
+				switch {
+				case key == "Transmitted" && !transmitted:
+					value, reset = json.RawMessage(`false`), true
+				case key == "Amendment" && !amended:
+					value, reset = json.RawMessage(`""`), true
+				}
+				if !reset {
+					value = values[i]
+				}
+				buf.Write(value)
+			}
+			buf.WriteByte('}')
+			signhash := md5.Sum(buf.Bytes())
+			if bytes.Equal(signhash[:], stored) {
+				return signhash[:], nil
+			}
+		}
+	}
+	return nil, ErrInvalidSignature
+}
+
+// objectFields returns the fields of a JSON object in their order, with their compact values.
+func objectFields(data []byte) ([]string, []json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, ErrInvalidSignature
+	}
+	var keys []string
+	var values []json.RawMessage
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, compact.Bytes())
+	}
+	return keys, values, nil
+}
+
+// legacySignatures returns the readings of a legacy signature as r and s. Each byte was written in decimal without
+// separator, so a signature reads in many ways; complete is false when there are more than maxLegacyCandidates.
+func legacySignatures(sign string) (candidates [][2]*big.Int, complete bool) {
+	// readings[i][k] counts the ways to read sign[i:] as k bytes
+	n := len(sign)
+	readings := make([][]int, n+1)
+	for i := range readings {
+		readings[i] = make([]int, 2*coordinateSize+1)
+	}
+	readings[n][0] = 1
+	for i := n - 1; i >= 0; i-- {
+		for l := 1; l <= 3 && i+l <= n; l++ {
+			if !legacyByte(sign[i : i+l]) {
+				break
+			}
+			for k := 1; k <= 2*coordinateSize; k++ {
+				readings[i][k] += readings[i+l][k-1]
+				if readings[i][k] > maxLegacyCandidates {
+					readings[i][k] = maxLegacyCandidates + 1
+				}
+			}
+		}
+	}
+	total := 0
+	for k := 2; k <= 2*coordinateSize; k++ {
+		total += readings[0][k] * (k - 1)
+		if total > maxLegacyCandidates {
+			return nil, false
+		}
+	}
+
+	// Every reading, split in r and s at every byte
+	var read func(i int, signature []byte)
+	read = func(i int, signature []byte) {
+		if i == n {
+			for split := 1; split < len(signature); split++ {
+				if split > coordinateSize || len(signature)-split > coordinateSize {
+					continue
+				}
+				r := new(big.Int).SetBytes(signature[:split])
+				s := new(big.Int).SetBytes(signature[split:])
+				candidates = append(candidates, [2]*big.Int{r, s})
+			}
+			return
+		}
+		if len(signature) == 2*coordinateSize {
+			return
+		}
+		value := 0
+		for l := 1; l <= 3 && i+l <= n; l++ {
+			if !legacyByte(sign[i : i+l]) {
+				break
+			}
+			value = value*10 + int(sign[i+l-1]-'0')
+			read(i+l, append(signature, byte(value)))
+		}
+	}
+	read(0, nil)
+	return candidates, true
+}
+
+// legacyByte reports whether a group of decimals is a byte written by strconv.Itoa.
+func legacyByte(digits string) bool {
+	value := 0
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return false
+		}
+		value = value*10 + int(digit-'0')
+	}
+	return value <= 255 && (len(digits) == 1 || digits[0] != '0')
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
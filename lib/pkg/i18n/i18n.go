@@ -0,0 +1,237 @@
+// File: ./blockfreight/lib/i18n/i18n.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package i18n provides the message catalog which localizes the validation and CLI messages.
+//
+// Messages are identified by keys (e.g. "validator.required") and written as fmt formats.
+// A key missing in a locale falls back to English, and an unknown key is printed as is.
+package i18n
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"io/ioutil"     // Implements some I/O utility functions.
+	"sort"          // Provides primitives for sorting slices and user-defined collections.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+	"sync"          // Provides basic synchronization primitives such as mutual exclusion locks.
+)
+
+// DefaultLocale is the locale of the messages when no supported locale is selected.
+const DefaultLocale = "en"
+
+// Messages maps the message keys to their fmt formats.
+type Messages map[string]string
+
+var (
+	mutex    sync.RWMutex
+	catalogs = map[string]Messages{
+		"en": english,
+		"es": spanish,
+		"it": italian,
+		"zh": chinese,
+		"ja": japanese,
+		"ar": arabic,
+	}
+	// builtin holds the locales shipped with the package, which Unregister keeps.
+	builtin = map[string]bool{"en": true, "es": true, "it": true, "zh": true, "ja": true, "ar": true}
+)
+
+// Catalog struct
+type Catalog struct {
+	locale string
+}
+
+// New returns the catalog of locale, or of the default locale when locale is not supported.
+func New(locale string) *Catalog {
+	return &Catalog{locale: Resolve(locale)}
+}
+
+// Default returns the catalog of the default locale.
+func Default() *Catalog {
+	return &Catalog{locale: DefaultLocale}
+}
+
+// Locale returns the locale of the catalog.
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+// T returns the message of key formatted with args.
+func (c *Catalog) T(key string, args ...interface{}) string {
+	mutex.RLock()
+	format, ok := catalogs[c.locale][key]
+	if !ok {
+		format, ok = catalogs[DefaultLocale][key]
+	}
+	mutex.RUnlock()
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Error returns the message of key formatted with args as an error.
+func (c *Catalog) Error(key string, args ...interface{}) error {
+	return errors.New(c.T(key, args...))
+}
+
+// Normalize returns the language of a locale identifier, e.g. "es" for "es_AR.UTF-8" or "zh-Hans".
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_-.@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
+
+// Resolve returns the first supported locale among the candidates, or the default locale.
+func Resolve(candidates ...string) string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	for _, candidate := range candidates {
+		if _, ok := catalogs[Normalize(candidate)]; ok {
+			return Normalize(candidate)
+		}
+	}
+	return DefaultLocale
+}
+
+// Supported returns the supported locales, sorted.
+func Supported() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Keys returns the message keys defined in the catalog of locale, sorted.
+func Keys(locale string) []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Register adds messages to the catalog of locale, replacing the existing ones, and adds the locale if needed.
+func Register(locale string, messages Messages) {
+	locale = Normalize(locale)
+	mutex.Lock()
+	defer mutex.Unlock()
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = Messages{}
+		catalogs[locale] = catalog
+	}
+	for key, format := range messages {
+		catalog[key] = format
+	}
+}
+
+// Unregister removes the catalog of a locale added by Register. The built-in locales are kept.
+func Unregister(locale string) {
+	locale = Normalize(locale)
+	mutex.Lock()
+	defer mutex.Unlock()
+	if !builtin[locale] {
+		delete(catalogs, locale)
+	}
+}
+
+// LoadFile registers the messages of locale stored in a JSON file of key/format pairs.
+func LoadFile(locale, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var messages Messages
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return err
+	}
+	Register(locale, messages)
+	return nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,216 @@
+// File: ./blockfreight/lib/i18n/messages_ar.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// arabic holds the Arabic messages; the command and flag usages fall back to English.
+var arabic = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "يجب أن تكون قيمة Type هي object.",
+	"validator.required":         "لا يمكن أن تكون القيمة فارغة.",
+	"validator.consignee":        "لا يوجد مرسل إليه، صدر BF_TX لأمر.",
+	"validator.vessel":           "تحتاج السفينة إلى Name أو IMONumber.",
+	"validator.imo-number":       "يجب أن يتكون IMONumber من 7 أرقام.",
+	"validator.cargo":            "يلزم بند شحنة واحد على الأقل.",
+	"validator.date-of-issue":    "تاريخ DateOfIssue يسبق DateShipped.",
+	"validator.num-bol":          "يجب أن يكون NumBol عددًا موجبًا.",
+	"validator.sign-algorithm":   "تم توقيع BF_TX بخوارزمية MD5 القديمة، ويجب توقيعه من جديد.",
+	"validator.date":             "يجب أن تكون القيمة تاريخًا بصيغة YYYY-MM-DD.",
+	"validator.country":          "يجب أن يكون Country رمز ISO 3166-1 من حرفين.",
+	"validator.email":            "يجب أن يكون Email عنوان بريد إلكتروني.",
+	"validator.port":             "يحتاج الميناء إلى Locode أو Name.",
+	"validator.locode":           "يجب أن يكون Locode رمز UN/LOCODE.",
+	"validator.locode-missing":   "يُعرَّف الميناء باسمه فقط.",
+	"validator.packages":         "لا يمكن أن تكون قيمة Packages سالبة.",
+	"validator.hs-code":          "يجب أن يتكون HSCode من 6 إلى 10 أرقام.",
+	"validator.hs-code-missing":  "لا يوجد رمز HS، ستقوم الجمارك بتصنيف البضائع.",
+	"validator.gross-weight":     "يجب أن يكون GrossWeight عددًا موجبًا.",
+	"validator.weight-unit":      "يجب أن تكون Unit وحدة وزن (KGM، TNE، LBR).",
+	"validator.volume":           "لا يمكن أن تكون قيمة Volume سالبة.",
+	"validator.volume-unit":      "يجب أن تكون Unit وحدة حجم (MTQ، FTQ، LTR).",
+	"validator.freight-terms":    "يجب أن تكون Terms هي PREPAID أو COLLECT.",
+	"validator.currency":         "يجب أن يكون Currency رمز ISO 4217.",
+	"validator.currency-missing": "تم تحديد مبالغ الشحن دون Currency.",
+	"validator.amount":           "لا يمكن أن تكون قيمة %s سالبة.",
+	"validator.invalid":          "BF_TX غير صالح: %s",
+	"validator.invalid-more":     "BF_TX غير صالح: %s (و%d أخطاء أخرى)",
+	"validator.severity.error":   "خطأ",
+	"validator.severity.warning": "تحذير",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "لا يُسمح بأي قيمة هنا.",
+	"schema.type":                 "المتوقع %s، والموجود %s.",
+	"schema.enum":                 "يجب أن تكون القيمة إحدى %s.",
+	"schema.const":                "يجب أن تكون القيمة %s.",
+	"schema.empty":                "لا يمكن أن تكون القيمة فارغة.",
+	"schema.minLength":            "يجب ألا يقل طول القيمة عن %v أحرف.",
+	"schema.maxLength":            "يجب ألا يزيد طول القيمة عن %v أحرف.",
+	"schema.pattern":              "القيمة %q لا تطابق %s.",
+	"schema.format":               "القيمة %q ليست %s صالحًا.",
+	"schema.minimum":              "يجب أن تكون القيمة أكبر من أو تساوي %v.",
+	"schema.maximum":              "يجب أن تكون القيمة أصغر من أو تساوي %v.",
+	"schema.exclusiveMinimum":     "يجب أن تكون القيمة أكبر من %v.",
+	"schema.exclusiveMaximum":     "يجب أن تكون القيمة أصغر من %v.",
+	"schema.multipleOf":           "يجب أن تكون القيمة من مضاعفات %v.",
+	"schema.minItems":             "يجب أن تحتوي المصفوفة على %v عناصر على الأقل.",
+	"schema.maxItems":             "يجب أن تحتوي المصفوفة على %v عناصر على الأكثر.",
+	"schema.uniqueItems":          "العنصران %d و%d متساويان.",
+	"schema.contains":             "لا تحتوي المصفوفة على أي عنصر مطابق.",
+	"schema.required":             "الخاصية مطلوبة.",
+	"schema.minProperties":        "يجب أن يحتوي الكائن على %v خصائص على الأقل.",
+	"schema.maxProperties":        "يجب أن يحتوي الكائن على %v خصائص على الأكثر.",
+	"schema.propertyNames":        "اسم الخاصية %q غير مسموح به.",
+	"schema.additionalProperties": "الخاصية غير مسموح بها.",
+	"schema.anyOf":                "القيمة لا تطابق أيًا من المخططات المسموح بها.%s",
+	"schema.oneOf":                "يجب أن تطابق القيمة مخططًا واحدًا فقط، وهي تطابق %d.%s",
+	"schema.not":                  "القيمة تطابق مخططًا محظورًا.",
+
+	// Command line interface
+	"cli.banner.address": "العنوان %s",
+	"cli.banner.call":    "تنفيذ BFT:  %s",
+	"cli.banner.title":   "تطبيق Blockfreight™ Go",
+
+	"cli.err.args":                   "يأخذ الأمر %s عدد %d من الوسائط",
+	"cli.err.args-named":             "يأخذ الأمر %s عدد %d من الوسائط (%s)",
+	"cli.err.line-too-long":          "سطر الإدخال طويل جدًا",
+	"cli.err.input-too-long":         "الإدخال طويل جدًا",
+	"cli.err.unknown-command":        "أمر غير معروف: %s",
+	"cli.err.try":                    "يرجى تجربة أحد الأوامر التالية:",
+	"cli.err.no-associated-bftx":     "لا يوجد BF_TX مرتبط بمحتوى JSON.",
+	"cli.err.already-amended":        "تم تعديل BF_TX %s بالفعل.",
+	"cli.err.proof-missing":          "استجابة الاستعلام لا تحتوي على إثبات، المفتاح غير موجود.",
+	"cli.err.proof-invalid":          "الإثبات لا يطابق الاستجابة و app hash.",
+	"cli.err.command-needs-key":      "الأمر %s يحتاج إلى الخيار --key",
+	"cli.err.unknown-state":          "حالة غير معروفة %s، استخدم إحدى: %s",
+	"cli.err.lifecycle-missing":      "لا توجد دورة حياة لـ BF_TX %s على التطبيق",
+	"cli.err.unknown-transfer":       "نقل غير معروف %s، استخدم endorse أو blank أو surrender",
+	"cli.err.title-missing":          "لا يملك BF_TX %s سند ملكية في التطبيق.",
+	"cli.err.revision-missing":       "لا يملك BF_TX %s مراجعة في التطبيق.",
+	"cli.err.hex-argument":           "خطأ في فك ترميز الوسيط الست عشري: %s",
+	"cli.err.string-argument":        "وسيط نصي غير صالح: \"%s\". يجب أن يكون بين علامتي اقتباس أو سلسلة ست عشرية تبدأ بـ \"0x\"",
+	"cli.err.index-missing":          "الفهارس مفقودة، شغّل bftx rebuild-index.",
+	"cli.err.unknown-format":         "صيغة غير معروفة %s، استخدم text أو json",
+	"cli.err.unknown-list-format":    "تنسيق غير معروف %s، استخدم table أو json أو csv",
+	"cli.err.invalid-cursor":         "مؤشر غير صالح، استخدم المؤشر الذي طبعته الصفحة السابقة بنفس --sort",
+	"cli.err.conflict":               "%d BF_TX في الأرشيف تتعارض مع قاعدة البيانات، لم يُستورد شيء: %s",
+	"cli.err.migration-failed":       "لا يمكن ترحيل %d BF_TX، لم يُكتب شيء",
+	"cli.err.already-signed":         "تم توقيع BF_TX مسبقًا.",
+	"cli.err.need-key":               "يحتاج الأمر sign إلى الخيار --key",
+	"cli.err.not-signed":             "لم يتم توقيع BF_TX بعد.",
+	"cli.err.already-transmitted":    "تم إرسال BF_TX مسبقًا.",
+	"cli.err.bftx-changed":           "تغيّر BF_TX %s أثناء توقيعه، وقّعه مرة أخرى.",
+	"cli.err.delivered-not-saved":    "تم تسليم BF_TX %s، لكن تعذّر وضع علامة الإرسال عليه في قاعدة البيانات: %s. أعد بثه لوضع العلامة.",
+	"cli.err.signer-not-in-keystore": "المفتاح الذي وقّع BF_TX غير موجود في مخزن المفاتيح.",
+	"cli.err.empty-passphrase":       "لا يمكن أن تكون عبارة المرور فارغة.",
+	"cli.err.file":                   "خطأ في الملف: %s",
+	"cli.err.config":                 "خطأ في الإعدادات: %s",
+
+	"cli.msg.associated-bftx":    "BF_TX المرتبط بمحتوى JSON هو %s",
+	"cli.msg.valid":              "نجاح! [OK] (%d تحذيرات)",
+	"cli.msg.bftx-id":            "معرّف BF_TX: %s",
+	"cli.msg.signed":             "تم توقيع BF_TX",
+	"cli.msg.signature-valid":    "توقيع BF_TX صالح. الموقّع %s",
+	"cli.msg.signer-key":         " (المفتاح %s)",
+	"cli.msg.signed-with":        " باستخدام %s",
+	"cli.msg.weak-signature":     ". تحذير: توقيع قديم ضعيف، يجب توقيع BF_TX من جديد",
+	"cli.msg.proof-valid":        "تم التحقق من الإثبات مقابل app hash %X",
+	"cli.msg.lifecycle-entry":    "الارتفاع %d: %s (بواسطة %s)",
+	"cli.msg.transfer":           "النقل %s لـ BF_TX %s",
+	"cli.msg.holder":             "الحامل: %s",
+	"cli.msg.holder-bearer":      "الحامل: %s (لحامله، مظهّر على بياض)",
+	"cli.msg.holder-surrendered": "سُلّم إلى الناقل بواسطة %s",
+	"cli.msg.endorsement":        "الارتفاع %d: %s من %s إلى %s",
+	"cli.msg.revision-original":  "الارتفاع %d: %s أصدره %s",
+	"cli.msg.revision":           "الارتفاع %d: %s يعدّل %s، اقترحه %s، التغييرات: %s",
+	"cli.msg.revision-approvals": "  وافق عليه: %s",
+	"cli.msg.revision-pending":   "معلّق: %s يعدّل %s، اقترحه %s، التغييرات: %s",
+	"cli.msg.revision-missing":   "  بانتظار: %s",
+	"cli.msg.state":              "حالة BF_TX: %s",
+	"cli.msg.total":              "إجمالي BF_TX في قاعدة البيانات: %d",
+	"cli.msg.index-rebuilt":      "أعيد بناء الفهارس: %d BF_TX مفهرسة",
+	"cli.msg.search":             "عرض %d من %d BF_TX",
+	"cli.msg.list-header":        "المعرف\tبوليصة الشحن\tالحالة\tالشاحن\tتاريخ الشحن\tالتعديل",
+	"cli.msg.next-page":          "الصفحة التالية: --cursor %s",
+	"cli.msg.exported":           "صُدّرت %d إدخالات (%d BF_TX) إلى %s",
+	"cli.msg.imported":           "استُورد %d BF_TX، و%d دون تغيير، و%d متخطاة",
+	"cli.msg.snapshot":           "نُسخ %d BF_TX إلى %s",
+	"cli.msg.migration-failure":  "BF_TX %s: %s",
+	"cli.msg.migration-plan":     "سيُرحَّل %d BF_TX من إصدار السجل %d إلى الإصدار %d",
+	"cli.msg.migrate-dry-run":    "سيُرحَّل %d BF_TX إلى إصدار السجل %d، و%d محدّثة بالفعل",
+	"cli.msg.migrated":           "رُحّل %d BF_TX إلى إصدار السجل %d، و%d محدّثة بالفعل",
+	"cli.msg.passphrase":         "عبارة المرور للمفتاح %s: ",
+	"cli.msg.key-created":        "تم إنشاء المفتاح %s. المفتاح العام: %s",
+	"cli.msg.key-imported":       "تم استيراد المفتاح %s. المفتاح العام: %s",
+	"cli.msg.keys":               "%d مفتاح",
+	"cli.msg.key-deleted":        "تم حذف المفتاح %s",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
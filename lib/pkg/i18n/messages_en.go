@@ -0,0 +1,283 @@
+// File: ./blockfreight/lib/i18n/messages_en.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// english holds the reference messages; every key used by the application is defined here.
+var english = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "Type must be object.",
+	"validator.required":         "Value must not be empty.",
+	"validator.consignee":        "No consignee, the BF_TX is made out to order.",
+	"validator.vessel":           "A vessel needs a Name or an IMONumber.",
+	"validator.imo-number":       "IMONumber must have 7 digits.",
+	"validator.cargo":            "At least one cargo item is required.",
+	"validator.date-of-issue":    "DateOfIssue is before DateShipped.",
+	"validator.num-bol":          "NumBol must be a positive number.",
+	"validator.sign-algorithm":   "The BF_TX is signed with the legacy MD5 algorithm, it must be signed again.",
+	"validator.date":             "Value must be a YYYY-MM-DD date.",
+	"validator.country":          "Country must be an ISO 3166-1 alpha-2 code.",
+	"validator.email":            "Email must be an email address.",
+	"validator.port":             "A port needs a Locode or a Name.",
+	"validator.locode":           "Locode must be a UN/LOCODE.",
+	"validator.locode-missing":   "The port is only identified by its name.",
+	"validator.packages":         "Packages must not be negative.",
+	"validator.hs-code":          "HSCode must have 6 to 10 digits.",
+	"validator.hs-code-missing":  "No HS code, customs will classify the goods.",
+	"validator.gross-weight":     "GrossWeight must be a positive number.",
+	"validator.weight-unit":      "Unit must be a weight unit (KGM, TNE, LBR).",
+	"validator.volume":           "Volume must not be negative.",
+	"validator.volume-unit":      "Unit must be a volume unit (MTQ, FTQ, LTR).",
+	"validator.freight-terms":    "Terms must be PREPAID or COLLECT.",
+	"validator.currency":         "Currency must be an ISO 4217 code.",
+	"validator.currency-missing": "Freight amounts are given without a Currency.",
+	"validator.amount":           "%s must not be negative.",
+	"validator.invalid":          "Invalid BF_TX: %s",
+	"validator.invalid-more":     "Invalid BF_TX: %s (and %d more errors)",
+	"validator.severity.error":   "error",
+	"validator.severity.warning": "warning",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "No value is allowed here.",
+	"schema.type":                 "Expected %s, found %s.",
+	"schema.enum":                 "Value must be one of %s.",
+	"schema.const":                "Value must be %s.",
+	"schema.empty":                "Value must not be empty.",
+	"schema.minLength":            "Value must be at least %v characters long.",
+	"schema.maxLength":            "Value must be at most %v characters long.",
+	"schema.pattern":              "Value %q does not match %s.",
+	"schema.format":               "Value %q is not a valid %s.",
+	"schema.minimum":              "Value must be greater than or equal to %v.",
+	"schema.maximum":              "Value must be less than or equal to %v.",
+	"schema.exclusiveMinimum":     "Value must be greater than %v.",
+	"schema.exclusiveMaximum":     "Value must be less than %v.",
+	"schema.multipleOf":           "Value must be a multiple of %v.",
+	"schema.minItems":             "Array must have at least %v items.",
+	"schema.maxItems":             "Array must have at most %v items.",
+	"schema.uniqueItems":          "Items %d and %d are equal.",
+	"schema.contains":             "Array does not contain any matching item.",
+	"schema.required":             "Property is required.",
+	"schema.minProperties":        "Object must have at least %v properties.",
+	"schema.maxProperties":        "Object must have at most %v properties.",
+	"schema.propertyNames":        "Property name %q is not allowed.",
+	"schema.additionalProperties": "Property is not allowed.",
+	"schema.anyOf":                "Value does not match any of the allowed schemas.%s",
+	"schema.oneOf":                "Value must match exactly one schema, it matches %d.%s",
+	"schema.not":                  "Value matches a forbidden schema.",
+
+	// Command line interface
+	"cli.banner.title":   "Blockfreight™ Go App",
+	"cli.banner.address": "Address %s",
+	"cli.banner.call":    "BFT Implementation:  %s",
+
+	"cli.flag.address":       "address of application socket",
+	"cli.flag.call":          "socket or grpc",
+	"cli.flag.verbose":       "print the command and results as if it were a console session",
+	"cli.flag.lang":          "language of the messages: %s (env BFTX_LANG, config lang, LANG)",
+	"cli.flag.config":        "configuration file",
+	"cli.flag.db":            "folder of the LevelDB (default: config database.path or %s)",
+	"cli.flag.keystore":      "directory where the signing keys are stored",
+	"cli.flag.schema":        "JSON Schema used to validate the BF_TX documents (default: config schema or %s)",
+	"cli.flag.json_path":     "define the source path where the json is",
+	"cli.flag.format":        "output format of the validation result: text or json",
+	"cli.flag.key":           "name of the keystore key used to sign",
+	"cli.flag.algorithm":     "signature algorithm: %s or %s",
+	"cli.flag.pubkey":        "hex encoded public key the signature must verify against (default: the embedded public key)",
+	"cli.flag.data":          "state key read by the /state path",
+	"cli.flag.height":        "height of the state to query, 0 for the last one",
+	"cli.flag.prove":         "return the Merkle proof of the value",
+	"cli.flag.app-hash":      "hex app hash the proof is verified against, locally (implies --prove)",
+	"cli.flag.offset":        "number of results to skip",
+	"cli.flag.limit":         "maximum number of results, 0 for all",
+	"cli.flag.search-format": "output format of the search result: text or json",
+	"cli.flag.cursor":        "cursor of the next page, printed by the previous page",
+	"cli.flag.state":         "only the BF_TX in this state: constructed, signed or transmitted",
+	"cli.flag.sort":          "sort order: %s, %s or %s",
+	"cli.flag.list-format":   "output format: table, json or csv",
+	"cli.flag.on-conflict":   "what to do with a BF_TX stored with another content: %s, %s or %s",
+	"cli.flag.dry-run":       "report the records to migrate without writing them",
+
+	"cli.cmd.batch":            "Run a batch of Blockfreight™ commands against an application",
+	"cli.cmd.console":          "Start an interactive Blockfreight™ console for multiple commands",
+	"cli.cmd.info":             "Get some info about the application (Parameters: none)",
+	"cli.cmd.set_option":       "Set an option on the application (Parameters: --Global Options, value)",
+	"cli.cmd.verify":           "Verify the JSON imput against a BF_TX (Parameters: JSON Filepath)",
+	"cli.cmd.validate":         "Validate a BF_TX (Parameters: JSON Filepath)",
+	"cli.cmd.construct":        "Construct a new BF_TX (Parameters: JSON Filepath)",
+	"cli.cmd.sign":             "Sign a new BF_TX (Parameters: BF_TX id, --key name)",
+	"cli.cmd.verify-signature": "Verify the signature of a BF_TX and report who signed it (Parameters: BF_TX id or JSON Filepath, --pubkey hex)",
+	"cli.cmd.keys":             "Manage the signing keys of the keystore",
+	"cli.cmd.keys.create":      "Create a new signing key (Parameters: name)",
+	"cli.cmd.keys.import":      "Import a hex encoded P-256 private key (Parameters: name, private key filepath)",
+	"cli.cmd.keys.list":        "List the signing keys (Parameters: none)",
+	"cli.cmd.keys.delete":      "Delete a signing key (Parameters: name)",
+	"cli.cmd.broadcast":        "Deliver a new BF_TX to application (Parameters: BF_TX id)",
+	"cli.cmd.commit":           "Commit the application state and return the Merkle root hash (Parameters: none)",
+	"cli.cmd.query":            "Query the application state (Parameters: path /bftx/{id}, /bftx/by-bol/{num}, /state or /size)",
+	"cli.cmd.get":              "Retrieve a [BF_TX] by its ID (Parameters: BF_TX id)",
+	"cli.cmd.append":           "Append a new BF_TX to an existing BF_TX in the DB, to sign and propose with amend (Parameters: JSON Filepath, BF_TX id)",
+	"cli.cmd.amend":            "Propose on the application a signed BF_TX appended in the DB as the amendment of a BF_TX (Parameters: BF_TX id, amendment BF_TX id, --key name)",
+	"cli.cmd.approve":          "Approve on the application the pending amendment of a BF_TX (Parameters: BF_TX id, amendment BF_TX id, --key name)",
+	"cli.cmd.history":          "Get every revision of a BF_TX from the application, with who changed what (Parameters: BF_TX id)",
+	"cli.cmd.state":            "Get the current state of a determined BF_TX (Parameters: BF_TX id)",
+	"cli.cmd.transition":       "Move a BF_TX to another state of its lifecycle on the application: %s (Parameters: BF_TX id, state, --key)",
+	"cli.cmd.lifecycle":        "Get the lifecycle state and history of a BF_TX from the application (Parameters: BF_TX id)",
+	"cli.cmd.transfer":         "Transfer the title of a negotiable BF_TX on the application (Parameters: BF_TX id, endorse|blank|surrender, endorsee public key for endorse and blank)",
+	"cli.cmd.holder":           "Get the holder and the endorsements of a BF_TX from the application (Parameters: BF_TX id)",
+	"cli.cmd.total":            "Query the total of BF_TX in DB (Parameters: none)",
+	"cli.cmd.list":             "List the BF_TX stored in the DB (Parameters: none)",
+	"cli.cmd.search":           "Search the BF_TX (Parameters: conditions field=value, field=prefix* or field=from..to, fields: %s)",
+	"cli.cmd.rebuild-index":    "Rebuild the indexes used by verify and search (Parameters: none)",
+	"cli.cmd.db":               "Back up and restore the DB",
+	"cli.cmd.db.export":        "Export every BF_TX and index to a checksummed archive (Parameters: archive filepath, default the standard output)",
+	"cli.cmd.db.import":        "Import an archive made by db export (Parameters: archive filepath or -, --on-conflict policy)",
+	"cli.cmd.db.migrate":       "Migrate the BF_TX records to the current storage format version (Parameters: none, --dry-run)",
+	"cli.cmd.db.snapshot":      "Copy a consistent snapshot of the DB, in use or not, to a new DB (Parameters: folder)",
+	"cli.cmd.echo":             "Print clearly a BF_TX (Parameters: BF_TX id)",
+	"cli.cmd.python":           "Test Python Hello Function",
+	"cli.cmd.exit":             "Leaves the program. (Parameters: none)",
+
+	"cli.err.args":                   "Command %s takes %d argument(s)",
+	"cli.err.args-named":             "Command %s takes %d argument(s) (%s)",
+	"cli.err.line-too-long":          "Input line is too long",
+	"cli.err.input-too-long":         "Input is too long",
+	"cli.err.unknown-command":        "Unknown command: %s",
+	"cli.err.try":                    "Please try one of the following:",
+	"cli.err.no-associated-bftx":     "JSON content does not have a BF_TX associated.",
+	"cli.err.already-amended":        "BF_TX %s is already amended.",
+	"cli.err.proof-missing":          "The query response has no proof, the key does not exist.",
+	"cli.err.proof-invalid":          "The proof does not match the response and the app hash.",
+	"cli.err.command-needs-key":      "Command %s needs the --key flag",
+	"cli.err.unknown-state":          "Unknown state %s, use one of: %s",
+	"cli.err.lifecycle-missing":      "BF_TX %s has no lifecycle on the application",
+	"cli.err.unknown-transfer":       "Unknown transfer %s, use endorse, blank or surrender",
+	"cli.err.title-missing":          "The BF_TX %s has no title on the application.",
+	"cli.err.revision-missing":       "The BF_TX %s has no revision on the application.",
+	"cli.err.index-missing":          "The indexes are missing, run bftx rebuild-index.",
+	"cli.err.unknown-format":         "Unknown format %s, use text or json",
+	"cli.err.unknown-list-format":    "Unknown format %s, use table, json or csv",
+	"cli.err.invalid-cursor":         "Invalid cursor, use the cursor printed by the previous page with the same --sort",
+	"cli.err.conflict":               "%d BF_TX of the archive conflict with the DB, nothing imported: %s",
+	"cli.err.migration-failed":       "%d BF_TX cannot be migrated, nothing was written",
+	"cli.err.already-signed":         "BF_TX already signed.",
+	"cli.err.need-key":               "Command sign needs the --key flag",
+	"cli.err.not-signed":             "BF_TX is not signed yet.",
+	"cli.err.already-transmitted":    "BF_TX already transmitted.",
+	"cli.err.bftx-changed":           "BF_TX %s changed while it was signed, sign it again.",
+	"cli.err.delivered-not-saved":    "BF_TX %s was delivered, but marking it as transmitted on the DB failed: %s. Broadcast it again to mark it.",
+	"cli.err.signer-not-in-keystore": "The key which signed the BF_TX is not in the keystore.",
+	"cli.err.empty-passphrase":       "Passphrase cannot be empty.",
+	"cli.err.file":                   "File error: %s",
+	"cli.err.hex-argument":           "Error decoding hex argument: %s",
+	"cli.err.string-argument":        "Invalid string arg: \"%s\". Must be quoted or a \"0x\"-prefixed hex string",
+	"cli.err.config":                 "Configuration error: %s",
+
+	"cli.msg.associated-bftx":    "The BF_TX associated to JSON content is %s",
+	"cli.msg.valid":              "Success! [OK] (%d warnings)",
+	"cli.msg.bftx-id":            "BF_TX Id: %s",
+	"cli.msg.signed":             "BF_TX signed",
+	"cli.msg.signature-valid":    "BF_TX signature is valid. Signed by %s",
+	"cli.msg.signer-key":         " (key %s)",
+	"cli.msg.signed-with":        " with %s",
+	"cli.msg.weak-signature":     ". WARNING: weak legacy signature, the BF_TX should be signed again",
+	"cli.msg.proof-valid":        "Proof verified against app hash %X",
+	"cli.msg.lifecycle-entry":    "Height %d: %s (by %s)",
+	"cli.msg.transfer":           "Transfer %s of BF_TX %s",
+	"cli.msg.holder":             "Holder: %s",
+	"cli.msg.holder-bearer":      "Holder: %s (bearer, endorsed in blank)",
+	"cli.msg.holder-surrendered": "Surrendered to the carrier by %s",
+	"cli.msg.endorsement":        "Height %d: %s from %s to %s",
+	"cli.msg.revision-original":  "Height %d: %s issued by %s",
+	"cli.msg.revision":           "Height %d: %s amends %s, proposed by %s, changed: %s",
+	"cli.msg.revision-approvals": "  approved by: %s",
+	"cli.msg.revision-pending":   "Pending: %s amends %s, proposed by %s, changed: %s",
+	"cli.msg.revision-missing":   "  waiting for: %s",
+	"cli.msg.state":              "BF_TX state: %s",
+	"cli.msg.total":              "Total BF_TX on BD: %d",
+	"cli.msg.index-rebuilt":      "Indexes rebuilt: %d BF_TX indexed",
+	"cli.msg.search":             "Showing %d of %d BF_TX",
+	"cli.msg.list-header":        "ID\tBOL\tSTATE\tSHIPPER\tSHIPPED\tAMENDMENT",
+	"cli.msg.next-page":          "Next page: --cursor %s",
+	"cli.msg.exported":           "%d entries (%d BF_TX) exported to %s",
+	"cli.msg.imported":           "%d BF_TX imported, %d unchanged, %d skipped",
+	"cli.msg.snapshot":           "%d BF_TX copied to %s",
+	"cli.msg.migration-failure":  "BF_TX %s: %s",
+	"cli.msg.migration-plan":     "%d BF_TX at record version %d will be migrated to version %d",
+	"cli.msg.migrate-dry-run":    "%d BF_TX would be migrated to record version %d, %d already current",
+	"cli.msg.migrated":           "%d BF_TX migrated to record version %d, %d already current",
+	"cli.msg.passphrase":         "Passphrase for key %s: ",
+	"cli.msg.key-created":        "Key %s created. Public key: %s",
+	"cli.msg.key-imported":       "Key %s imported. Public key: %s",
+	"cli.msg.keys":               "%d key(s)",
+	"cli.msg.key-deleted":        "Key %s deleted",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,283 @@
+// File: ./blockfreight/lib/i18n/messages_es.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// spanish holds the Spanish messages.
+var spanish = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "Type debe ser object.",
+	"validator.required":         "El valor no puede estar vacío.",
+	"validator.consignee":        "Sin consignatario, el BF_TX se emite a la orden.",
+	"validator.vessel":           "El buque necesita un Name o un IMONumber.",
+	"validator.imo-number":       "IMONumber debe tener 7 dígitos.",
+	"validator.cargo":            "Se requiere al menos una línea de carga.",
+	"validator.date-of-issue":    "DateOfIssue es anterior a DateShipped.",
+	"validator.num-bol":          "NumBol debe ser un número positivo.",
+	"validator.sign-algorithm":   "El BF_TX está firmado con el algoritmo heredado MD5, debe firmarse de nuevo.",
+	"validator.date":             "El valor debe ser una fecha AAAA-MM-DD.",
+	"validator.country":          "Country debe ser un código ISO 3166-1 alfa-2.",
+	"validator.email":            "Email debe ser una dirección de correo electrónico.",
+	"validator.port":             "El puerto necesita un Locode o un Name.",
+	"validator.locode":           "Locode debe ser un UN/LOCODE.",
+	"validator.locode-missing":   "El puerto solo se identifica por su nombre.",
+	"validator.packages":         "Packages no puede ser negativo.",
+	"validator.hs-code":          "HSCode debe tener de 6 a 10 dígitos.",
+	"validator.hs-code-missing":  "Sin código SA, la aduana clasificará la mercancía.",
+	"validator.gross-weight":     "GrossWeight debe ser un número positivo.",
+	"validator.weight-unit":      "Unit debe ser una unidad de peso (KGM, TNE, LBR).",
+	"validator.volume":           "Volume no puede ser negativo.",
+	"validator.volume-unit":      "Unit debe ser una unidad de volumen (MTQ, FTQ, LTR).",
+	"validator.freight-terms":    "Terms debe ser PREPAID o COLLECT.",
+	"validator.currency":         "Currency debe ser un código ISO 4217.",
+	"validator.currency-missing": "Se indican importes de flete sin Currency.",
+	"validator.amount":           "%s no puede ser negativo.",
+	"validator.invalid":          "BF_TX inválido: %s",
+	"validator.invalid-more":     "BF_TX inválido: %s (y %d errores más)",
+	"validator.severity.error":   "error",
+	"validator.severity.warning": "advertencia",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "No se admite ningún valor aquí.",
+	"schema.type":                 "Se esperaba %s, se encontró %s.",
+	"schema.enum":                 "El valor debe ser uno de %s.",
+	"schema.const":                "El valor debe ser %s.",
+	"schema.empty":                "El valor no puede estar vacío.",
+	"schema.minLength":            "El valor debe tener al menos %v caracteres.",
+	"schema.maxLength":            "El valor debe tener como máximo %v caracteres.",
+	"schema.pattern":              "El valor %q no coincide con %s.",
+	"schema.format":               "El valor %q no es un %s válido.",
+	"schema.minimum":              "El valor debe ser mayor o igual que %v.",
+	"schema.maximum":              "El valor debe ser menor o igual que %v.",
+	"schema.exclusiveMinimum":     "El valor debe ser mayor que %v.",
+	"schema.exclusiveMaximum":     "El valor debe ser menor que %v.",
+	"schema.multipleOf":           "El valor debe ser múltiplo de %v.",
+	"schema.minItems":             "La lista debe tener al menos %v elementos.",
+	"schema.maxItems":             "La lista debe tener como máximo %v elementos.",
+	"schema.uniqueItems":          "Los elementos %d y %d son iguales.",
+	"schema.contains":             "La lista no contiene ningún elemento válido.",
+	"schema.required":             "La propiedad es obligatoria.",
+	"schema.minProperties":        "El objeto debe tener al menos %v propiedades.",
+	"schema.maxProperties":        "El objeto debe tener como máximo %v propiedades.",
+	"schema.propertyNames":        "El nombre de propiedad %q no está permitido.",
+	"schema.additionalProperties": "La propiedad no está permitida.",
+	"schema.anyOf":                "El valor no coincide con ninguno de los esquemas permitidos.%s",
+	"schema.oneOf":                "El valor debe coincidir con exactamente un esquema, coincide con %d.%s",
+	"schema.not":                  "El valor coincide con un esquema prohibido.",
+
+	// Command line interface
+	"cli.banner.title":   "Blockfreight™ Go App",
+	"cli.banner.address": "Dirección %s",
+	"cli.banner.call":    "Implementación BFT:  %s",
+
+	"cli.flag.address":       "dirección del socket de la aplicación",
+	"cli.flag.call":          "socket o grpc",
+	"cli.flag.verbose":       "imprime el comando y los resultados como en una sesión de consola",
+	"cli.flag.lang":          "idioma de los mensajes: %s (env BFTX_LANG, config lang, LANG)",
+	"cli.flag.config":        "archivo de configuración",
+	"cli.flag.db":            "carpeta de la LevelDB (por defecto: database.path de la configuración o %s)",
+	"cli.flag.keystore":      "directorio donde se guardan las claves de firma",
+	"cli.flag.schema":        "JSON Schema usado para validar los documentos BF_TX (por defecto: schema de la configuración o %s)",
+	"cli.flag.json_path":     "define la ruta de origen donde está el json",
+	"cli.flag.format":        "formato de salida del resultado de la validación: text o json",
+	"cli.flag.key":           "nombre de la clave del almacén usada para firmar",
+	"cli.flag.algorithm":     "algoritmo de firma: %s o %s",
+	"cli.flag.pubkey":        "clave pública en hexadecimal con la que se verifica la firma (por defecto: la clave pública incluida)",
+	"cli.flag.data":          "clave del estado leída por la ruta /state",
+	"cli.flag.height":        "altura del estado a consultar, 0 para la última",
+	"cli.flag.prove":         "devolver la prueba de Merkle del valor",
+	"cli.flag.app-hash":      "app hash en hex contra el que se verifica la prueba, localmente (implica --prove)",
+	"cli.flag.offset":        "número de resultados que se saltan",
+	"cli.flag.limit":         "número máximo de resultados, 0 para todos",
+	"cli.flag.search-format": "formato de salida del resultado de la búsqueda: text o json",
+	"cli.flag.cursor":        "cursor de la página siguiente, impreso por la página anterior",
+	"cli.flag.state":         "solo los BF_TX en este estado: constructed, signed o transmitted",
+	"cli.flag.sort":          "orden: %s, %s o %s",
+	"cli.flag.list-format":   "formato de salida: table, json o csv",
+	"cli.flag.on-conflict":   "qué hacer con un BF_TX guardado con otro contenido: %s, %s o %s",
+	"cli.flag.dry-run":       "informa de los registros a migrar sin escribirlos",
+
+	"cli.cmd.batch":            "Ejecuta un lote de comandos Blockfreight™ contra una aplicación",
+	"cli.cmd.console":          "Inicia una consola interactiva Blockfreight™ para varios comandos",
+	"cli.cmd.info":             "Obtiene información de la aplicación (Parámetros: ninguno)",
+	"cli.cmd.set_option":       "Establece una opción de la aplicación (Parámetros: --Opciones globales, valor)",
+	"cli.cmd.verify":           "Verifica el JSON de entrada contra un BF_TX (Parámetros: ruta del JSON)",
+	"cli.cmd.validate":         "Valida un BF_TX (Parámetros: ruta del JSON)",
+	"cli.cmd.construct":        "Construye un nuevo BF_TX (Parámetros: ruta del JSON)",
+	"cli.cmd.sign":             "Firma un nuevo BF_TX (Parámetros: id del BF_TX, --key nombre)",
+	"cli.cmd.verify-signature": "Verifica la firma de un BF_TX e indica quién lo firmó (Parámetros: id del BF_TX o ruta del JSON, --pubkey hex)",
+	"cli.cmd.keys":             "Gestiona las claves de firma del almacén",
+	"cli.cmd.keys.create":      "Crea una nueva clave de firma (Parámetros: nombre)",
+	"cli.cmd.keys.import":      "Importa una clave privada P-256 en hexadecimal (Parámetros: nombre, ruta de la clave privada)",
+	"cli.cmd.keys.list":        "Lista las claves de firma (Parámetros: ninguno)",
+	"cli.cmd.keys.delete":      "Elimina una clave de firma (Parámetros: nombre)",
+	"cli.cmd.broadcast":        "Entrega un nuevo BF_TX a la aplicación (Parámetros: id del BF_TX)",
+	"cli.cmd.commit":           "Confirma el estado de la aplicación y devuelve la raíz de Merkle (Parámetros: ninguno)",
+	"cli.cmd.query":            "Consultar el estado de la aplicación (Parámetros: ruta /bftx/{id}, /bftx/by-bol/{num}, /state o /size)",
+	"cli.cmd.get":              "Recupera un [BF_TX] por su ID (Parámetros: id del BF_TX)",
+	"cli.cmd.append":           "Añade un nuevo BF_TX a un BF_TX existente en la BD, para firmarlo y proponerlo con amend (Parámetros: ruta del JSON, id del BF_TX)",
+	"cli.cmd.amend":            "Proponer en la aplicación un BF_TX firmado y añadido en la BD como enmienda de un BF_TX (Parámetros: id del BF_TX, id del BF_TX de la enmienda, --key nombre)",
+	"cli.cmd.approve":          "Aprobar en la aplicación la enmienda pendiente de un BF_TX (Parámetros: id del BF_TX, id del BF_TX de la enmienda, --key nombre)",
+	"cli.cmd.history":          "Obtener todas las revisiones de un BF_TX desde la aplicación, con quién cambió qué (Parámetros: id del BF_TX)",
+	"cli.cmd.state":            "Obtiene el estado actual de un BF_TX (Parámetros: id del BF_TX)",
+	"cli.cmd.transition":       "Mover un BF_TX a otro estado de su ciclo de vida en la aplicación: %s (Parámetros: id del BF_TX, estado, --key)",
+	"cli.cmd.lifecycle":        "Obtener el estado y el historial del ciclo de vida de un BF_TX desde la aplicación (Parámetros: id del BF_TX)",
+	"cli.cmd.transfer":         "Transferir el título de un BF_TX negociable en la aplicación (Parámetros: id del BF_TX, endorse|blank|surrender, clave pública del endosatario para endorse y blank)",
+	"cli.cmd.holder":           "Obtener el tenedor y los endosos de un BF_TX desde la aplicación (Parámetros: id del BF_TX)",
+	"cli.cmd.total":            "Consulta el total de BF_TX en la BD (Parámetros: ninguno)",
+	"cli.cmd.list":             "Lista los BF_TX guardados en la BD (Parámetros: ninguno)",
+	"cli.cmd.search":           "Busca los BF_TX (Parámetros: condiciones campo=valor, campo=prefijo* o campo=desde..hasta, campos: %s)",
+	"cli.cmd.rebuild-index":    "Reconstruye los índices que usan verify y search (Parámetros: ninguno)",
+	"cli.cmd.db":               "Copia de seguridad y restauración de la BD",
+	"cli.cmd.db.export":        "Exporta todos los BF_TX e índices a un archivo con suma de verificación (Parámetros: ruta del archivo, por defecto la salida estándar)",
+	"cli.cmd.db.import":        "Importa un archivo hecho con db export (Parámetros: ruta del archivo o -, --on-conflict política)",
+	"cli.cmd.db.migrate":       "Migra los registros BF_TX a la versión actual del formato de almacenamiento (Parámetros: ninguno, --dry-run)",
+	"cli.cmd.db.snapshot":      "Copia una instantánea consistente de la BD, en uso o no, a una BD nueva (Parámetros: carpeta)",
+	"cli.cmd.echo":             "Imprime un BF_TX de forma legible (Parámetros: id del BF_TX)",
+	"cli.cmd.python":           "Prueba la función Hello de Python",
+	"cli.cmd.exit":             "Sale del programa. (Parámetros: ninguno)",
+
+	"cli.err.args":                   "El comando %s recibe %d argumento(s)",
+	"cli.err.args-named":             "El comando %s recibe %d argumento(s) (%s)",
+	"cli.err.line-too-long":          "La línea de entrada es demasiado larga",
+	"cli.err.input-too-long":         "La entrada es demasiado larga",
+	"cli.err.unknown-command":        "Comando desconocido: %s",
+	"cli.err.try":                    "Pruebe uno de los siguientes:",
+	"cli.err.no-associated-bftx":     "El contenido JSON no tiene un BF_TX asociado.",
+	"cli.err.already-amended":        "El BF_TX %s ya tiene una enmienda.",
+	"cli.err.proof-missing":          "La respuesta de la consulta no tiene prueba, la clave no existe.",
+	"cli.err.proof-invalid":          "La prueba no coincide con la respuesta y el app hash.",
+	"cli.err.command-needs-key":      "El comando %s necesita la opción --key",
+	"cli.err.unknown-state":          "Estado desconocido %s, use uno de: %s",
+	"cli.err.lifecycle-missing":      "El BF_TX %s no tiene ciclo de vida en la aplicación",
+	"cli.err.unknown-transfer":       "Transferencia desconocida %s, use endorse, blank o surrender",
+	"cli.err.title-missing":          "El BF_TX %s no tiene título en la aplicación.",
+	"cli.err.revision-missing":       "El BF_TX %s no tiene revisión en la aplicación.",
+	"cli.err.index-missing":          "Faltan los índices, ejecute bftx rebuild-index.",
+	"cli.err.unknown-format":         "Formato %s desconocido, use text o json",
+	"cli.err.unknown-list-format":    "Formato %s desconocido, use table, json o csv",
+	"cli.err.invalid-cursor":         "Cursor no válido, use el cursor impreso por la página anterior con el mismo --sort",
+	"cli.err.conflict":               "%d BF_TX del archivo entran en conflicto con la BD, no se importó nada: %s",
+	"cli.err.migration-failed":       "%d BF_TX no se pueden migrar, no se escribió nada",
+	"cli.err.already-signed":         "El BF_TX ya está firmado.",
+	"cli.err.need-key":               "El comando sign necesita la opción --key",
+	"cli.err.not-signed":             "El BF_TX aún no está firmado.",
+	"cli.err.already-transmitted":    "El BF_TX ya fue transmitido.",
+	"cli.err.bftx-changed":           "El BF_TX %s cambió mientras se firmaba, fírmelo de nuevo.",
+	"cli.err.delivered-not-saved":    "El BF_TX %s fue entregado, pero no se pudo marcar como transmitido en la BD: %s. Transmítalo de nuevo para marcarlo.",
+	"cli.err.signer-not-in-keystore": "La clave que firmó el BF_TX no está en el almacén.",
+	"cli.err.empty-passphrase":       "La frase de contraseña no puede estar vacía.",
+	"cli.err.file":                   "Error de archivo: %s",
+	"cli.err.hex-argument":           "Error al decodificar el argumento hexadecimal: %s",
+	"cli.err.string-argument":        "Argumento inválido: \"%s\". Debe ir entre comillas o ser hexadecimal con prefijo \"0x\"",
+	"cli.err.config":                 "Error de configuración: %s",
+
+	"cli.msg.associated-bftx":    "El BF_TX asociado al contenido JSON es %s",
+	"cli.msg.valid":              "¡Éxito! [OK] (%d advertencias)",
+	"cli.msg.bftx-id":            "Id del BF_TX: %s",
+	"cli.msg.signed":             "BF_TX firmado",
+	"cli.msg.signature-valid":    "La firma del BF_TX es válida. Firmado por %s",
+	"cli.msg.signer-key":         " (clave %s)",
+	"cli.msg.signed-with":        " con %s",
+	"cli.msg.weak-signature":     ". AVISO: firma heredada débil, el BF_TX debería firmarse de nuevo",
+	"cli.msg.proof-valid":        "Prueba verificada contra el app hash %X",
+	"cli.msg.lifecycle-entry":    "Altura %d: %s (por %s)",
+	"cli.msg.transfer":           "Transferencia %s del BF_TX %s",
+	"cli.msg.holder":             "Tenedor: %s",
+	"cli.msg.holder-bearer":      "Tenedor: %s (portador, endosado en blanco)",
+	"cli.msg.holder-surrendered": "Entregado al transportista por %s",
+	"cli.msg.endorsement":        "Altura %d: %s de %s a %s",
+	"cli.msg.revision-original":  "Altura %d: %s emitido por %s",
+	"cli.msg.revision":           "Altura %d: %s enmienda %s, propuesto por %s, cambios: %s",
+	"cli.msg.revision-approvals": "  aprobado por: %s",
+	"cli.msg.revision-pending":   "Pendiente: %s enmienda %s, propuesto por %s, cambios: %s",
+	"cli.msg.revision-missing":   "  esperando a: %s",
+	"cli.msg.state":              "Estado del BF_TX: %s",
+	"cli.msg.total":              "Total de BF_TX en la BD: %d",
+	"cli.msg.index-rebuilt":      "Índices reconstruidos: %d BF_TX indexados",
+	"cli.msg.search":             "Mostrando %d de %d BF_TX",
+	"cli.msg.list-header":        "ID\tBL\tESTADO\tCARGADOR\tEMBARQUE\tENMIENDA",
+	"cli.msg.next-page":          "Página siguiente: --cursor %s",
+	"cli.msg.exported":           "%d entradas (%d BF_TX) exportadas a %s",
+	"cli.msg.imported":           "%d BF_TX importados, %d sin cambios, %d omitidos",
+	"cli.msg.snapshot":           "%d BF_TX copiados a %s",
+	"cli.msg.migration-failure":  "BF_TX %s: %s",
+	"cli.msg.migration-plan":     "%d BF_TX en la versión de registro %d se migrarán a la versión %d",
+	"cli.msg.migrate-dry-run":    "%d BF_TX se migrarían a la versión de registro %d, %d ya actualizados",
+	"cli.msg.migrated":           "%d BF_TX migrados a la versión de registro %d, %d ya actualizados",
+	"cli.msg.passphrase":         "Frase de contraseña de la clave %s: ",
+	"cli.msg.key-created":        "Clave %s creada. Clave pública: %s",
+	"cli.msg.key-imported":       "Clave %s importada. Clave pública: %s",
+	"cli.msg.keys":               "%d clave(s)",
+	"cli.msg.key-deleted":        "Clave %s eliminada",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
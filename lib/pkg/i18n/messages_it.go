@@ -0,0 +1,216 @@
+// File: ./blockfreight/lib/i18n/messages_it.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// italian holds the Italian messages; the command and flag usages fall back to English.
+var italian = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "Type deve essere object.",
+	"validator.required":         "Il valore non può essere vuoto.",
+	"validator.consignee":        "Nessun destinatario, il BF_TX è emesso all'ordine.",
+	"validator.vessel":           "La nave richiede un Name o un IMONumber.",
+	"validator.imo-number":       "IMONumber deve avere 7 cifre.",
+	"validator.cargo":            "È richiesta almeno una riga di carico.",
+	"validator.date-of-issue":    "DateOfIssue è precedente a DateShipped.",
+	"validator.num-bol":          "NumBol deve essere un numero positivo.",
+	"validator.sign-algorithm":   "Il BF_TX è firmato con il vecchio algoritmo MD5, deve essere firmato di nuovo.",
+	"validator.date":             "Il valore deve essere una data AAAA-MM-GG.",
+	"validator.country":          "Country deve essere un codice ISO 3166-1 alpha-2.",
+	"validator.email":            "Email deve essere un indirizzo email.",
+	"validator.port":             "Il porto richiede un Locode o un Name.",
+	"validator.locode":           "Locode deve essere un UN/LOCODE.",
+	"validator.locode-missing":   "Il porto è identificato solo dal nome.",
+	"validator.packages":         "Packages non può essere negativo.",
+	"validator.hs-code":          "HSCode deve avere da 6 a 10 cifre.",
+	"validator.hs-code-missing":  "Nessun codice SA, la dogana classificherà la merce.",
+	"validator.gross-weight":     "GrossWeight deve essere un numero positivo.",
+	"validator.weight-unit":      "Unit deve essere un'unità di peso (KGM, TNE, LBR).",
+	"validator.volume":           "Volume non può essere negativo.",
+	"validator.volume-unit":      "Unit deve essere un'unità di volume (MTQ, FTQ, LTR).",
+	"validator.freight-terms":    "Terms deve essere PREPAID o COLLECT.",
+	"validator.currency":         "Currency deve essere un codice ISO 4217.",
+	"validator.currency-missing": "Importi di nolo indicati senza Currency.",
+	"validator.amount":           "%s non può essere negativo.",
+	"validator.invalid":          "BF_TX non valido: %s",
+	"validator.invalid-more":     "BF_TX non valido: %s (e altri %d errori)",
+	"validator.severity.error":   "errore",
+	"validator.severity.warning": "avviso",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "Nessun valore è ammesso qui.",
+	"schema.type":                 "Atteso %s, trovato %s.",
+	"schema.enum":                 "Il valore deve essere uno tra %s.",
+	"schema.const":                "Il valore deve essere %s.",
+	"schema.empty":                "Il valore non può essere vuoto.",
+	"schema.minLength":            "Il valore deve avere almeno %v caratteri.",
+	"schema.maxLength":            "Il valore deve avere al massimo %v caratteri.",
+	"schema.pattern":              "Il valore %q non corrisponde a %s.",
+	"schema.format":               "Il valore %q non è un %s valido.",
+	"schema.minimum":              "Il valore deve essere maggiore o uguale a %v.",
+	"schema.maximum":              "Il valore deve essere minore o uguale a %v.",
+	"schema.exclusiveMinimum":     "Il valore deve essere maggiore di %v.",
+	"schema.exclusiveMaximum":     "Il valore deve essere minore di %v.",
+	"schema.multipleOf":           "Il valore deve essere un multiplo di %v.",
+	"schema.minItems":             "L'elenco deve avere almeno %v elementi.",
+	"schema.maxItems":             "L'elenco deve avere al massimo %v elementi.",
+	"schema.uniqueItems":          "Gli elementi %d e %d sono uguali.",
+	"schema.contains":             "L'elenco non contiene alcun elemento valido.",
+	"schema.required":             "La proprietà è obbligatoria.",
+	"schema.minProperties":        "L'oggetto deve avere almeno %v proprietà.",
+	"schema.maxProperties":        "L'oggetto deve avere al massimo %v proprietà.",
+	"schema.propertyNames":        "Il nome di proprietà %q non è ammesso.",
+	"schema.additionalProperties": "La proprietà non è ammessa.",
+	"schema.anyOf":                "Il valore non corrisponde a nessuno degli schemi ammessi.%s",
+	"schema.oneOf":                "Il valore deve corrispondere a esattamente uno schema, ne corrisponde a %d.%s",
+	"schema.not":                  "Il valore corrisponde a uno schema vietato.",
+
+	// Command line interface
+	"cli.banner.address": "Indirizzo %s",
+	"cli.banner.call":    "Implementazione BFT:  %s",
+	"cli.banner.title":   "Blockfreight™ Go App",
+
+	"cli.err.args":                   "Il comando %s accetta %d argomento/i",
+	"cli.err.args-named":             "Il comando %s accetta %d argomento/i (%s)",
+	"cli.err.line-too-long":          "La riga di input è troppo lunga",
+	"cli.err.input-too-long":         "L'input è troppo lungo",
+	"cli.err.unknown-command":        "Comando sconosciuto: %s",
+	"cli.err.try":                    "Prova uno dei seguenti:",
+	"cli.err.no-associated-bftx":     "Il contenuto JSON non ha un BF_TX associato.",
+	"cli.err.already-amended":        "Il BF_TX %s ha già un emendamento.",
+	"cli.err.proof-missing":          "La risposta della query non ha una prova, la chiave non esiste.",
+	"cli.err.proof-invalid":          "La prova non corrisponde alla risposta e all'app hash.",
+	"cli.err.command-needs-key":      "Il comando %s richiede l'opzione --key",
+	"cli.err.unknown-state":          "Stato sconosciuto %s, usare uno tra: %s",
+	"cli.err.lifecycle-missing":      "Il BF_TX %s non ha un ciclo di vita nell'applicazione",
+	"cli.err.unknown-transfer":       "Trasferimento sconosciuto %s, usare endorse, blank o surrender",
+	"cli.err.title-missing":          "Il BF_TX %s non ha un titolo nell'applicazione.",
+	"cli.err.revision-missing":       "Il BF_TX %s non ha una revisione nell'applicazione.",
+	"cli.err.hex-argument":           "Errore nella decodifica dell'argomento esadecimale: %s",
+	"cli.err.string-argument":        "Argomento non valido: \"%s\". Deve essere tra virgolette o una stringa esadecimale con prefisso \"0x\"",
+	"cli.err.index-missing":          "Mancano gli indici, eseguire bftx rebuild-index.",
+	"cli.err.unknown-format":         "Formato %s sconosciuto, usa text o json",
+	"cli.err.unknown-list-format":    "Formato %s sconosciuto, usare table, json o csv",
+	"cli.err.invalid-cursor":         "Cursore non valido, usare il cursore stampato dalla pagina precedente con lo stesso --sort",
+	"cli.err.conflict":               "%d BF_TX dell'archivio sono in conflitto con il DB, nulla importato: %s",
+	"cli.err.migration-failed":       "%d BF_TX non possono essere migrati, nulla è stato scritto",
+	"cli.err.already-signed":         "BF_TX già firmato.",
+	"cli.err.need-key":               "Il comando sign richiede l'opzione --key",
+	"cli.err.not-signed":             "Il BF_TX non è ancora firmato.",
+	"cli.err.already-transmitted":    "BF_TX già trasmesso.",
+	"cli.err.bftx-changed":           "BF_TX %s è cambiato durante la firma, firmalo di nuovo.",
+	"cli.err.delivered-not-saved":    "BF_TX %s è stato consegnato, ma non è stato possibile segnarlo come trasmesso nel DB: %s. Trasmettilo di nuovo per segnarlo.",
+	"cli.err.signer-not-in-keystore": "La chiave che ha firmato il BF_TX non è nell'archivio.",
+	"cli.err.empty-passphrase":       "La passphrase non può essere vuota.",
+	"cli.err.file":                   "Errore di file: %s",
+	"cli.err.config":                 "Errore di configurazione: %s",
+
+	"cli.msg.associated-bftx":    "Il BF_TX associato al contenuto JSON è %s",
+	"cli.msg.valid":              "Successo! [OK] (%d avvisi)",
+	"cli.msg.bftx-id":            "Id del BF_TX: %s",
+	"cli.msg.signed":             "BF_TX firmato",
+	"cli.msg.signature-valid":    "La firma del BF_TX è valida. Firmato da %s",
+	"cli.msg.signer-key":         " (chiave %s)",
+	"cli.msg.signed-with":        " con %s",
+	"cli.msg.weak-signature":     ". ATTENZIONE: firma debole, il BF_TX dovrebbe essere firmato di nuovo",
+	"cli.msg.proof-valid":        "Prova verificata rispetto all'app hash %X",
+	"cli.msg.lifecycle-entry":    "Altezza %d: %s (da %s)",
+	"cli.msg.transfer":           "Trasferimento %s del BF_TX %s",
+	"cli.msg.holder":             "Detentore: %s",
+	"cli.msg.holder-bearer":      "Detentore: %s (portatore, girato in bianco)",
+	"cli.msg.holder-surrendered": "Consegnato al vettore da %s",
+	"cli.msg.endorsement":        "Altezza %d: %s da %s a %s",
+	"cli.msg.revision-original":  "Altezza %d: %s emesso da %s",
+	"cli.msg.revision":           "Altezza %d: %s emenda %s, proposto da %s, modifiche: %s",
+	"cli.msg.revision-approvals": "  approvato da: %s",
+	"cli.msg.revision-pending":   "In attesa: %s emenda %s, proposto da %s, modifiche: %s",
+	"cli.msg.revision-missing":   "  in attesa di: %s",
+	"cli.msg.state":              "Stato del BF_TX: %s",
+	"cli.msg.total":              "Totale BF_TX nel DB: %d",
+	"cli.msg.index-rebuilt":      "Indici ricostruiti: %d BF_TX indicizzati",
+	"cli.msg.search":             "Mostrati %d di %d BF_TX",
+	"cli.msg.list-header":        "ID\tBL\tSTATO\tCARICATORE\tIMBARCO\tEMENDAMENTO",
+	"cli.msg.next-page":          "Pagina successiva: --cursor %s",
+	"cli.msg.exported":           "%d voci (%d BF_TX) esportate in %s",
+	"cli.msg.imported":           "%d BF_TX importati, %d invariati, %d saltati",
+	"cli.msg.snapshot":           "%d BF_TX copiati in %s",
+	"cli.msg.migration-failure":  "BF_TX %s: %s",
+	"cli.msg.migration-plan":     "%d BF_TX alla versione di record %d saranno migrati alla versione %d",
+	"cli.msg.migrate-dry-run":    "%d BF_TX sarebbero migrati alla versione di record %d, %d già aggiornati",
+	"cli.msg.migrated":           "%d BF_TX migrati alla versione di record %d, %d già aggiornati",
+	"cli.msg.passphrase":         "Passphrase della chiave %s: ",
+	"cli.msg.key-created":        "Chiave %s creata. Chiave pubblica: %s",
+	"cli.msg.key-imported":       "Chiave %s importata. Chiave pubblica: %s",
+	"cli.msg.keys":               "%d chiave/i",
+	"cli.msg.key-deleted":        "Chiave %s eliminata",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,216 @@
+// File: ./blockfreight/lib/i18n/messages_ja.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// japanese holds the Japanese messages; the command and flag usages fall back to English.
+var japanese = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "Type は object でなければなりません。",
+	"validator.required":         "値を空にすることはできません。",
+	"validator.consignee":        "荷受人がないため、この BF_TX は指図式です。",
+	"validator.vessel":           "船舶には Name または IMONumber が必要です。",
+	"validator.imo-number":       "IMONumber は 7 桁でなければなりません。",
+	"validator.cargo":            "貨物明細が少なくとも 1 件必要です。",
+	"validator.date-of-issue":    "DateOfIssue が DateShipped より前です。",
+	"validator.num-bol":          "NumBol は正の数でなければなりません。",
+	"validator.sign-algorithm":   "この BF_TX は旧式の MD5 アルゴリズムで署名されています。再署名が必要です。",
+	"validator.date":             "値は YYYY-MM-DD 形式の日付でなければなりません。",
+	"validator.country":          "Country は ISO 3166-1 alpha-2 コードでなければなりません。",
+	"validator.email":            "Email はメールアドレスでなければなりません。",
+	"validator.port":             "港には Locode または Name が必要です。",
+	"validator.locode":           "Locode は UN/LOCODE でなければなりません。",
+	"validator.locode-missing":   "この港は名前でのみ識別されています。",
+	"validator.packages":         "Packages を負の数にすることはできません。",
+	"validator.hs-code":          "HSCode は 6 ～ 10 桁でなければなりません。",
+	"validator.hs-code-missing":  "HS コードがないため、税関が貨物を分類します。",
+	"validator.gross-weight":     "GrossWeight は正の数でなければなりません。",
+	"validator.weight-unit":      "Unit は重量単位 (KGM、TNE、LBR) でなければなりません。",
+	"validator.volume":           "Volume を負の数にすることはできません。",
+	"validator.volume-unit":      "Unit は容積単位 (MTQ、FTQ、LTR) でなければなりません。",
+	"validator.freight-terms":    "Terms は PREPAID または COLLECT でなければなりません。",
+	"validator.currency":         "Currency は ISO 4217 コードでなければなりません。",
+	"validator.currency-missing": "運賃額に Currency が指定されていません。",
+	"validator.amount":           "%s を負の数にすることはできません。",
+	"validator.invalid":          "無効な BF_TX: %s",
+	"validator.invalid-more":     "無効な BF_TX: %s (他に %d 件のエラー)",
+	"validator.severity.error":   "エラー",
+	"validator.severity.warning": "警告",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "ここには値を指定できません。",
+	"schema.type":                 "%s が必要ですが、%s が指定されています。",
+	"schema.enum":                 "値は %s のいずれかでなければなりません。",
+	"schema.const":                "値は %s でなければなりません。",
+	"schema.empty":                "値を空にすることはできません。",
+	"schema.minLength":            "値は %v 文字以上でなければなりません。",
+	"schema.maxLength":            "値は %v 文字以下でなければなりません。",
+	"schema.pattern":              "値 %q は %s に一致しません。",
+	"schema.format":               "値 %q は有効な %s ではありません。",
+	"schema.minimum":              "値は %v 以上でなければなりません。",
+	"schema.maximum":              "値は %v 以下でなければなりません。",
+	"schema.exclusiveMinimum":     "値は %v より大きくなければなりません。",
+	"schema.exclusiveMaximum":     "値は %v より小さくなければなりません。",
+	"schema.multipleOf":           "値は %v の倍数でなければなりません。",
+	"schema.minItems":             "配列には %v 個以上の要素が必要です。",
+	"schema.maxItems":             "配列の要素は %v 個以下でなければなりません。",
+	"schema.uniqueItems":          "要素 %d と %d が同じです。",
+	"schema.contains":             "配列に条件を満たす要素がありません。",
+	"schema.required":             "このプロパティは必須です。",
+	"schema.minProperties":        "オブジェクトには %v 個以上のプロパティが必要です。",
+	"schema.maxProperties":        "オブジェクトのプロパティは %v 個以下でなければなりません。",
+	"schema.propertyNames":        "プロパティ名 %q は使用できません。",
+	"schema.additionalProperties": "このプロパティは使用できません。",
+	"schema.anyOf":                "値はどの許可されたスキーマにも一致しません。%s",
+	"schema.oneOf":                "値はちょうど 1 つのスキーマに一致する必要がありますが、%d 個に一致しています。%s",
+	"schema.not":                  "値は禁止されたスキーマに一致しています。",
+
+	// Command line interface
+	"cli.banner.address": "アドレス %s",
+	"cli.banner.call":    "BFT 実装:  %s",
+	"cli.banner.title":   "Blockfreight™ Go アプリ",
+
+	"cli.err.args":                   "コマンド %s の引数は %d 個です",
+	"cli.err.args-named":             "コマンド %s の引数は %d 個です (%s)",
+	"cli.err.line-too-long":          "入力行が長すぎます",
+	"cli.err.input-too-long":         "入力が長すぎます",
+	"cli.err.unknown-command":        "不明なコマンド: %s",
+	"cli.err.try":                    "次のいずれかを試してください:",
+	"cli.err.no-associated-bftx":     "この JSON の内容に関連付けられた BF_TX はありません。",
+	"cli.err.already-amended":        "BF_TX %s は既に修正されています。",
+	"cli.err.proof-missing":          "クエリの応答に証明がありません。キーが存在しません。",
+	"cli.err.proof-invalid":          "証明が応答と app hash に一致しません。",
+	"cli.err.command-needs-key":      "コマンド %s には --key フラグが必要です",
+	"cli.err.unknown-state":          "不明な状態 %s です。次のいずれかを使用してください: %s",
+	"cli.err.lifecycle-missing":      "BF_TX %s にはアプリケーション上のライフサイクルがありません",
+	"cli.err.unknown-transfer":       "不明な譲渡 %s です。endorse、blank、surrender のいずれかを使用してください",
+	"cli.err.title-missing":          "BF_TX %s にはアプリケーション上の権原がありません。",
+	"cli.err.revision-missing":       "BF_TX %s にはアプリケーション上の改訂がありません。",
+	"cli.err.hex-argument":           "16 進数の引数のデコードに失敗しました: %s",
+	"cli.err.string-argument":        "無効な文字列引数です: \"%s\"。引用符で囲むか、\"0x\" で始まる 16 進数文字列にしてください",
+	"cli.err.index-missing":          "インデックスがありません。bftx rebuild-index を実行してください。",
+	"cli.err.unknown-format":         "不明な形式 %s です。text または json を使用してください",
+	"cli.err.unknown-list-format":    "不明な形式 %s です。table、json、csv のいずれかを使用してください",
+	"cli.err.invalid-cursor":         "無効なカーソルです。同じ --sort で前のページが表示したカーソルを使用してください",
+	"cli.err.conflict":               "アーカイブの %d 件の BF_TX が DB と競合するため、何もインポートしませんでした: %s",
+	"cli.err.migration-failed":       "%d 件の BF_TX を移行できないため、何も書き込みませんでした",
+	"cli.err.already-signed":         "BF_TX は署名済みです。",
+	"cli.err.need-key":               "sign コマンドには --key オプションが必要です",
+	"cli.err.not-signed":             "BF_TX はまだ署名されていません。",
+	"cli.err.already-transmitted":    "BF_TX は送信済みです。",
+	"cli.err.bftx-changed":           "BF_TX %s は署名中に変更されました。もう一度署名してください。",
+	"cli.err.delivered-not-saved":    "BF_TX %s は配信されましたが、DB で送信済みにできませんでした: %s。もう一度ブロードキャストして記録してください。",
+	"cli.err.signer-not-in-keystore": "BF_TX に署名した鍵がキーストアにありません。",
+	"cli.err.empty-passphrase":       "パスフレーズを空にすることはできません。",
+	"cli.err.file":                   "ファイルエラー: %s",
+	"cli.err.config":                 "設定エラー: %s",
+
+	"cli.msg.associated-bftx":    "この JSON の内容に関連付けられた BF_TX は %s です",
+	"cli.msg.valid":              "成功しました! [OK] (警告 %d 件)",
+	"cli.msg.bftx-id":            "BF_TX Id: %s",
+	"cli.msg.signed":             "BF_TX に署名しました",
+	"cli.msg.signature-valid":    "BF_TX の署名は有効です。署名者 %s",
+	"cli.msg.signer-key":         " (鍵 %s)",
+	"cli.msg.signed-with":        "、アルゴリズム %s",
+	"cli.msg.weak-signature":     "。警告: 旧式の弱い署名です。BF_TX に再署名してください",
+	"cli.msg.proof-valid":        "app hash %X に対して証明を検証しました",
+	"cli.msg.lifecycle-entry":    "高さ %d: %s (%s による)",
+	"cli.msg.transfer":           "譲渡 %s (BF_TX %s)",
+	"cli.msg.holder":             "所持人: %s",
+	"cli.msg.holder-bearer":      "所持人: %s (持参人、白地裏書)",
+	"cli.msg.holder-surrendered": "%s により運送人に返還済み",
+	"cli.msg.endorsement":        "高さ %d: %s %s から %s へ",
+	"cli.msg.revision-original":  "高さ %d: %s (%s が発行)",
+	"cli.msg.revision":           "高さ %d: %s は %s を修正 (%s が提案)、変更: %s",
+	"cli.msg.revision-approvals": "  承認者: %s",
+	"cli.msg.revision-pending":   "保留中: %s は %s を修正 (%s が提案)、変更: %s",
+	"cli.msg.revision-missing":   "  承認待ち: %s",
+	"cli.msg.state":              "BF_TX の状態: %s",
+	"cli.msg.total":              "DB 内の BF_TX の総数: %d",
+	"cli.msg.index-rebuilt":      "インデックスを再構築しました: %d 件の BF_TX",
+	"cli.msg.search":             "%d 件を表示 (全 %d 件の BF_TX)",
+	"cli.msg.list-header":        "ID\tB/L\t状態\t荷送人\t船積日\t修正",
+	"cli.msg.next-page":          "次のページ: --cursor %s",
+	"cli.msg.exported":           "%d 件のエントリ (%d 件の BF_TX) を %s にエクスポートしました",
+	"cli.msg.imported":           "%d 件の BF_TX をインポート、%d 件は変更なし、%d 件はスキップ",
+	"cli.msg.snapshot":           "%d 件の BF_TX を %s にコピーしました",
+	"cli.msg.migration-failure":  "BF_TX %s: %s",
+	"cli.msg.migration-plan":     "%d 件の BF_TX (レコードバージョン %d) をバージョン %d に移行します",
+	"cli.msg.migrate-dry-run":    "%d 件の BF_TX をレコードバージョン %d に移行します (%d 件は最新)",
+	"cli.msg.migrated":           "%d 件の BF_TX をレコードバージョン %d に移行しました (%d 件は最新)",
+	"cli.msg.passphrase":         "鍵 %s のパスフレーズ: ",
+	"cli.msg.key-created":        "鍵 %s を作成しました。公開鍵: %s",
+	"cli.msg.key-imported":       "鍵 %s をインポートしました。公開鍵: %s",
+	"cli.msg.keys":               "鍵 %d 個",
+	"cli.msg.key-deleted":        "鍵 %s を削除しました",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,216 @@
+// File: ./blockfreight/lib/i18n/messages_zh.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package i18n
+
+// chinese holds the Simplified Chinese messages; the command and flag usages fall back to English.
+var chinese = Messages{
+	// Validation of the BF_TX fields
+	"validator.type":             "Type 必须为 object。",
+	"validator.required":         "值不能为空。",
+	"validator.consignee":        "没有收货人，该 BF_TX 为指示提单。",
+	"validator.vessel":           "船舶需要 Name 或 IMONumber。",
+	"validator.imo-number":       "IMONumber 必须为 7 位数字。",
+	"validator.cargo":            "至少需要一项货物。",
+	"validator.date-of-issue":    "DateOfIssue 早于 DateShipped。",
+	"validator.num-bol":          "NumBol 必须为正数。",
+	"validator.sign-algorithm":   "该 BF_TX 使用旧的 MD5 算法签名，必须重新签名。",
+	"validator.date":             "值必须为 YYYY-MM-DD 格式的日期。",
+	"validator.country":          "Country 必须为 ISO 3166-1 二字母代码。",
+	"validator.email":            "Email 必须为电子邮件地址。",
+	"validator.port":             "港口需要 Locode 或 Name。",
+	"validator.locode":           "Locode 必须为 UN/LOCODE。",
+	"validator.locode-missing":   "该港口仅以名称标识。",
+	"validator.packages":         "Packages 不能为负数。",
+	"validator.hs-code":          "HSCode 必须为 6 至 10 位数字。",
+	"validator.hs-code-missing":  "没有 HS 编码，海关将对货物进行归类。",
+	"validator.gross-weight":     "GrossWeight 必须为正数。",
+	"validator.weight-unit":      "Unit 必须为重量单位（KGM、TNE、LBR）。",
+	"validator.volume":           "Volume 不能为负数。",
+	"validator.volume-unit":      "Unit 必须为体积单位（MTQ、FTQ、LTR）。",
+	"validator.freight-terms":    "Terms 必须为 PREPAID 或 COLLECT。",
+	"validator.currency":         "Currency 必须为 ISO 4217 代码。",
+	"validator.currency-missing": "运费金额未指定 Currency。",
+	"validator.amount":           "%s 不能为负数。",
+	"validator.invalid":          "无效的 BF_TX：%s",
+	"validator.invalid-more":     "无效的 BF_TX：%s（另有 %d 个错误）",
+	"validator.severity.error":   "错误",
+	"validator.severity.warning": "警告",
+
+	// Validation of the raw JSON against the JSON Schema
+	"schema.false":                "此处不允许任何值。",
+	"schema.type":                 "应为 %s，实际为 %s。",
+	"schema.enum":                 "值必须为 %s 之一。",
+	"schema.const":                "值必须为 %s。",
+	"schema.empty":                "值不能为空。",
+	"schema.minLength":            "值至少需要 %v 个字符。",
+	"schema.maxLength":            "值最多 %v 个字符。",
+	"schema.pattern":              "值 %q 不匹配 %s。",
+	"schema.format":               "值 %q 不是有效的 %s。",
+	"schema.minimum":              "值必须大于或等于 %v。",
+	"schema.maximum":              "值必须小于或等于 %v。",
+	"schema.exclusiveMinimum":     "值必须大于 %v。",
+	"schema.exclusiveMaximum":     "值必须小于 %v。",
+	"schema.multipleOf":           "值必须为 %v 的倍数。",
+	"schema.minItems":             "数组至少需要 %v 项。",
+	"schema.maxItems":             "数组最多 %v 项。",
+	"schema.uniqueItems":          "第 %d 项与第 %d 项相同。",
+	"schema.contains":             "数组中没有符合条件的项。",
+	"schema.required":             "该属性为必填项。",
+	"schema.minProperties":        "对象至少需要 %v 个属性。",
+	"schema.maxProperties":        "对象最多 %v 个属性。",
+	"schema.propertyNames":        "不允许的属性名 %q。",
+	"schema.additionalProperties": "不允许该属性。",
+	"schema.anyOf":                "值不匹配任何允许的模式。%s",
+	"schema.oneOf":                "值必须恰好匹配一个模式，实际匹配 %d 个。%s",
+	"schema.not":                  "值匹配了被禁止的模式。",
+
+	// Command line interface
+	"cli.banner.address": "地址 %s",
+	"cli.banner.call":    "BFT 实现：  %s",
+	"cli.banner.title":   "Blockfreight™ Go 应用",
+
+	"cli.err.args":                   "命令 %s 需要 %d 个参数",
+	"cli.err.args-named":             "命令 %s 需要 %d 个参数（%s）",
+	"cli.err.line-too-long":          "输入行过长",
+	"cli.err.input-too-long":         "输入过长",
+	"cli.err.unknown-command":        "未知命令：%s",
+	"cli.err.try":                    "请尝试以下命令之一：",
+	"cli.err.no-associated-bftx":     "该 JSON 内容没有关联的 BF_TX。",
+	"cli.err.already-amended":        "BF_TX %s 已被修订。",
+	"cli.err.proof-missing":          "查询响应没有证明，该键不存在。",
+	"cli.err.proof-invalid":          "证明与响应和 app hash 不匹配。",
+	"cli.err.command-needs-key":      "命令 %s 需要 --key 选项",
+	"cli.err.unknown-state":          "未知状态 %s，请使用以下之一：%s",
+	"cli.err.lifecycle-missing":      "BF_TX %s 在应用中没有生命周期",
+	"cli.err.unknown-transfer":       "未知转让 %s，请使用 endorse、blank 或 surrender",
+	"cli.err.title-missing":          "BF_TX %s 在应用中没有所有权记录。",
+	"cli.err.revision-missing":       "BF_TX %s 在应用中没有修订记录。",
+	"cli.err.hex-argument":           "解码十六进制参数出错：%s",
+	"cli.err.string-argument":        "无效的字符串参数：\"%s\"。必须加引号或为带 \"0x\" 前缀的十六进制字符串",
+	"cli.err.index-missing":          "缺少索引，请运行 bftx rebuild-index。",
+	"cli.err.unknown-format":         "未知格式 %s，请使用 text 或 json",
+	"cli.err.unknown-list-format":    "未知格式 %s，请使用 table、json 或 csv",
+	"cli.err.invalid-cursor":         "无效的游标，请使用上一页以相同 --sort 打印的游标",
+	"cli.err.conflict":               "归档中有 %d 个 BF_TX 与数据库冲突，未导入任何内容：%s",
+	"cli.err.migration-failed":       "%d 个 BF_TX 无法迁移，未写入任何内容",
+	"cli.err.already-signed":         "BF_TX 已签名。",
+	"cli.err.need-key":               "sign 命令需要 --key 选项",
+	"cli.err.not-signed":             "BF_TX 尚未签名。",
+	"cli.err.already-transmitted":    "BF_TX 已传输。",
+	"cli.err.bftx-changed":           "BF_TX %s 在签名期间已更改，请重新签名。",
+	"cli.err.delivered-not-saved":    "BF_TX %s 已交付，但未能在数据库中标记为已传输：%s。请再次广播以进行标记。",
+	"cli.err.signer-not-in-keystore": "签署该 BF_TX 的密钥不在密钥库中。",
+	"cli.err.empty-passphrase":       "口令不能为空。",
+	"cli.err.file":                   "文件错误：%s",
+	"cli.err.config":                 "配置错误：%s",
+
+	"cli.msg.associated-bftx":    "与该 JSON 内容关联的 BF_TX 为 %s",
+	"cli.msg.valid":              "成功！[OK]（%d 个警告）",
+	"cli.msg.bftx-id":            "BF_TX Id：%s",
+	"cli.msg.signed":             "BF_TX 已签名",
+	"cli.msg.signature-valid":    "BF_TX 签名有效。签名者 %s",
+	"cli.msg.signer-key":         "（密钥 %s）",
+	"cli.msg.signed-with":        "，算法 %s",
+	"cli.msg.weak-signature":     "。警告：旧的弱签名，应重新签署该 BF_TX",
+	"cli.msg.proof-valid":        "已根据 app hash %X 验证证明",
+	"cli.msg.lifecycle-entry":    "高度 %d：%s（由 %s）",
+	"cli.msg.transfer":           "BF_TX 转让 %s：%s",
+	"cli.msg.holder":             "持有人：%s",
+	"cli.msg.holder-bearer":      "持有人：%s（持票人，空白背书）",
+	"cli.msg.holder-surrendered": "已由 %s 交还承运人",
+	"cli.msg.endorsement":        "高度 %d：%s 从 %s 到 %s",
+	"cli.msg.revision-original":  "高度 %d：%s 由 %s 签发",
+	"cli.msg.revision":           "高度 %d：%s 修订 %s，由 %s 提议，变更：%s",
+	"cli.msg.revision-approvals": "  批准方：%s",
+	"cli.msg.revision-pending":   "待定：%s 修订 %s，由 %s 提议，变更：%s",
+	"cli.msg.revision-missing":   "  等待：%s",
+	"cli.msg.state":              "BF_TX 状态：%s",
+	"cli.msg.total":              "数据库中的 BF_TX 总数：%d",
+	"cli.msg.index-rebuilt":      "索引已重建：已索引 %d 个 BF_TX",
+	"cli.msg.search":             "显示 %d 个，共 %d 个 BF_TX",
+	"cli.msg.list-header":        "ID\t提单号\t状态\t发货人\t装运日期\t修订",
+	"cli.msg.next-page":          "下一页：--cursor %s",
+	"cli.msg.exported":           "已导出 %d 个条目（%d 个 BF_TX）到 %s",
+	"cli.msg.imported":           "已导入 %d 个 BF_TX，%d 个未变，%d 个跳过",
+	"cli.msg.snapshot":           "已复制 %d 个 BF_TX 到 %s",
+	"cli.msg.migration-failure":  "BF_TX %s：%s",
+	"cli.msg.migration-plan":     "%d 个记录版本为 %d 的 BF_TX 将迁移到版本 %d",
+	"cli.msg.migrate-dry-run":    "%d 个 BF_TX 将迁移到记录版本 %d，%d 个已是最新",
+	"cli.msg.migrated":           "%d 个 BF_TX 已迁移到记录版本 %d，%d 个已是最新",
+	"cli.msg.passphrase":         "密钥 %s 的口令：",
+	"cli.msg.key-created":        "已创建密钥 %s。公钥：%s",
+	"cli.msg.key-imported":       "已导入密钥 %s。公钥：%s",
+	"cli.msg.keys":               "%d 个密钥",
+	"cli.msg.key-deleted":        "已删除密钥 %s",
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
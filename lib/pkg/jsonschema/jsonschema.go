@@ -0,0 +1,735 @@
+// File: ./blockfreight/lib/jsonschema/jsonschema.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package jsonschema provides a JSON Schema (draft-07) validator for JSON documents.
+package jsonschema
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"         // Implements functions for the manipulation of byte slices.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"fmt"           // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"io/ioutil"     // Implements some I/O utility functions.
+	"math"          // Provides basic constants and mathematical functions.
+	"net/mail"      // Implements parsing of mail messages.
+	"reflect"       // Implements run-time reflection, allowing a program to manipulate objects with arbitrary types.
+	"regexp"        // Implements regular expression search.
+	"sort"          // Provides primitives for sorting slices and user-defined collections.
+	"strings"       // Implements simple functions to manipulate UTF-8 encoded strings.
+	"time"          // Provides functionality for measuring and displaying time.
+	"unicode/utf8"  // Implements functions and constants to support text encoded in UTF-8.
+)
+
+// Dialects of JSON Schema accepted in the $schema keyword.
+var dialects = map[string]bool{
+	"http://json-schema.org/draft-07/schema":       true,
+	"http://json-schema.org/draft-07/schema#":      true,
+	"https://json-schema.org/draft/2019-09/schema": true,
+	"https://json-schema.org/draft/2020-12/schema": true,
+}
+
+var (
+	// ErrInvalidSchema is returned when the schema document is not a valid schema.
+	ErrInvalidSchema = errors.New("Invalid JSON Schema.")
+	// ErrInvalidDocument is returned when the validated document is not valid JSON.
+	ErrInvalidDocument = errors.New("Invalid JSON document.")
+)
+
+// Schema struct
+type Schema struct {
+	root    interface{}
+	regexps map[string]*regexp.Regexp
+	looping map[uintptr]bool // Subschemas checked by checkLoop, by map identity: true while on the path.
+}
+
+// Violation struct
+type Violation struct {
+	Pointer string        // JSON pointer (RFC 6901) of the offending value, "" for the document root.
+	Keyword string        // Schema keyword which failed.
+	Message string        // English message.
+	Params  []interface{} // Arguments of the message, so it can be rendered in other languages.
+}
+
+// String returns the violation as "#<pointer>: <message>".
+func (v Violation) String() string {
+	return "#" + v.Pointer + ": " + v.Message
+}
+
+// Load reads and parses the schema stored in path.
+func Load(path string) (*Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses a schema document, checking every keyword, regular expression and $ref in it. References which loop
+// back to a schema without going down the document, e.g. a $ref to itself, are invalid.
+func Parse(data []byte) (*Schema, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("%s %s", ErrInvalidSchema.Error(), err.Error())
+	}
+	schema := &Schema{root: root, regexps: make(map[string]*regexp.Regexp), looping: make(map[uintptr]bool)}
+	if object, ok := root.(map[string]interface{}); ok {
+		if dialect, ok := object["$schema"].(string); ok && !dialects[dialect] {
+			return nil, fmt.Errorf("%s Unsupported $schema %s.", ErrInvalidSchema.Error(), dialect)
+		}
+	}
+	if err := schema.check(root, ""); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ID returns the $id of the schema, which identifies its version.
+func (s *Schema) ID() string {
+	if object, ok := s.root.(map[string]interface{}); ok {
+		if id, ok := object["$id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// Validate validates the JSON document data and returns every violation found, sorted by pointer.
+// The error is only set when data is not a JSON document.
+func (s *Schema) Validate(data []byte) ([]Violation, error) {
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%s %s", ErrInvalidDocument.Error(), err.Error())
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%s Unexpected data after the top-level value.", ErrInvalidDocument.Error())
+	}
+	violations := s.validate(s.root, document, "")
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Pointer < violations[j].Pointer
+	})
+	return violations, nil
+}
+
+// check walks a (sub)schema, compiling its patterns and resolving its references.
+func (s *Schema) check(node interface{}, pointer string) error {
+	switch schema := node.(type) {
+	case bool:
+		return nil
+	case map[string]interface{}:
+		if ref, ok := schema["$ref"].(string); ok {
+			if _, err := s.resolve(ref); err != nil {
+				return fmt.Errorf("%s %s at #%s.", ErrInvalidSchema.Error(), err.Error(), pointer)
+			}
+		}
+		if err := s.checkLoop(schema, pointer); err != nil {
+			return err
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if _, err := s.compile(pattern); err != nil {
+				return fmt.Errorf("%s Bad pattern at #%s: %s", ErrInvalidSchema.Error(), pointer, err.Error())
+			}
+		}
+		for key, value := range schema {
+			switch key {
+			case "properties", "patternProperties", "definitions", "$defs":
+				children, ok := value.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s %s is not an object at #%s.", ErrInvalidSchema.Error(), key, pointer)
+				}
+				for name, child := range children {
+					if key == "patternProperties" {
+						if _, err := s.compile(name); err != nil {
+							return fmt.Errorf("%s Bad pattern at #%s: %s", ErrInvalidSchema.Error(), pointer, err.Error())
+						}
+					}
+					if err := s.check(child, pointer+"/"+key+"/"+escape(name)); err != nil {
+						return err
+					}
+				}
+			case "allOf", "anyOf", "oneOf":
+				children, ok := value.([]interface{})
+				if !ok {
+					return fmt.Errorf("%s %s is not an array at #%s.", ErrInvalidSchema.Error(), key, pointer)
+				}
+				for i, child := range children {
+					if err := s.check(child, fmt.Sprintf("%s/%s/%d", pointer, key, i)); err != nil {
+						return err
+					}
+				}
+			case "items":
+				if children, ok := value.([]interface{}); ok {
+					for i, child := range children {
+						if err := s.check(child, fmt.Sprintf("%s/items/%d", pointer, i)); err != nil {
+							return err
+						}
+					}
+				} else if err := s.check(value, pointer+"/items"); err != nil {
+					return err
+				}
+			case "additionalProperties", "additionalItems", "not", "if", "then", "else", "contains", "propertyNames":
+				if err := s.check(value, pointer+"/"+key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%s Schema at #%s is neither an object nor a boolean.", ErrInvalidSchema.Error(), pointer)
+}
+
+// checkLoop refuses a subschema which is applied again to the same value by its references and in-place keywords,
+// which would validate forever.
+func (s *Schema) checkLoop(node interface{}, pointer string) error {
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	id := reflect.ValueOf(schema).Pointer()
+	if onPath, checked := s.looping[id]; checked {
+		if onPath {
+			return fmt.Errorf("%s Reference cycle at #%s.", ErrInvalidSchema.Error(), pointer)
+		}
+		return nil
+	}
+	s.looping[id] = true
+	for _, next := range s.inPlace(schema) {
+		if err := s.checkLoop(next, pointer); err != nil {
+			return err
+		}
+	}
+	s.looping[id] = false
+	return nil
+}
+
+// inPlace returns the subschemas which validate the same value as schema.
+func (s *Schema) inPlace(schema map[string]interface{}) []interface{} {
+	if ref, ok := schema["$ref"].(string); ok {
+		// In draft-07 $ref overrides any sibling keyword
+		target, _ := s.resolve(ref)
+		return []interface{}{target}
+	}
+	var subschemas []interface{}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if children, ok := schema[key].([]interface{}); ok {
+			subschemas = append(subschemas, children...)
+		}
+	}
+	for _, key := range []string{"not", "if", "then", "else"} {
+		if child, ok := schema[key]; ok {
+			subschemas = append(subschemas, child)
+		}
+	}
+	return subschemas
+}
+
+// resolve returns the subschema referenced by a local reference ("#" or "#/json/pointer").
+func (s *Schema) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("Only local references are supported, got %s", ref)
+	}
+	node := s.root
+	if ref == "#" {
+		return node, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("Unresolvable reference %s", ref)
+	}
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unresolvable reference %s", ref)
+		}
+		if node, ok = object[token]; !ok {
+			return nil, fmt.Errorf("Unresolvable reference %s", ref)
+		}
+	}
+	return node, nil
+}
+
+// compile returns the compiled regular expression, caching it.
+func (s *Schema) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := s.regexps[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	s.regexps[pattern] = re
+	return re, nil
+}
+
+// validate validates value, found at pointer, against node.
+func (s *Schema) validate(node interface{}, value interface{}, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	switch schema := node.(type) {
+	case bool:
+		if !schema {
+			fail("false", "No value is allowed here.")
+		}
+		return violations
+	case map[string]interface{}:
+		if ref, ok := schema["$ref"].(string); ok {
+			// In draft-07 $ref overrides any sibling keyword
+			target, _ := s.resolve(ref)
+			return s.validate(target, value, pointer)
+		}
+
+		if types, ok := schema["type"]; ok && !matchesType(types, value) {
+			fail("type", "Expected %s, found %s.", describeType(types), typeOf(value))
+			// The other keywords would only repeat the type mismatch
+			return violations
+		}
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			found := false
+			for _, allowed := range enum {
+				if reflect.DeepEqual(allowed, value) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				fail("enum", "Value must be one of %s.", compact(enum))
+			}
+		}
+		if constant, ok := schema["const"]; ok && !reflect.DeepEqual(constant, value) {
+			fail("const", "Value must be %s.", compact(constant))
+		}
+
+		switch v := value.(type) {
+		case string:
+			violations = append(violations, s.validateString(schema, v, pointer)...)
+		case float64:
+			violations = append(violations, validateNumber(schema, v, pointer)...)
+		case []interface{}:
+			violations = append(violations, s.validateArray(schema, v, pointer)...)
+		case map[string]interface{}:
+			violations = append(violations, s.validateObject(schema, v, pointer)...)
+		}
+
+		violations = append(violations, s.validateCombinators(schema, value, pointer)...)
+	}
+	return violations
+}
+
+// validateString applies the string keywords.
+func (s *Schema) validateString(schema map[string]interface{}, value, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	length := utf8.RuneCountInString(value)
+	if min, ok := schema["minLength"].(float64); ok && float64(length) < min {
+		if min == 1 {
+			fail("minLength", "Value must not be empty.")
+		} else {
+			fail("minLength", "Value must be at least %v characters long.", min)
+		}
+	}
+	if max, ok := schema["maxLength"].(float64); ok && float64(length) > max {
+		fail("maxLength", "Value must be at most %v characters long.", max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := s.compile(pattern); err == nil && !re.MatchString(value) {
+			fail("pattern", "Value %q does not match %s.", value, pattern)
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !matchesFormat(format, value) {
+		fail("format", "Value %q is not a valid %s.", value, format)
+	}
+	return violations
+}
+
+// validateNumber applies the numeric keywords.
+func validateNumber(schema map[string]interface{}, value float64, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	if min, ok := schema["minimum"].(float64); ok && value < min {
+		fail("minimum", "Value must be greater than or equal to %v.", min)
+	}
+	if max, ok := schema["maximum"].(float64); ok && value > max {
+		fail("maximum", "Value must be less than or equal to %v.", max)
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && value <= min {
+		fail("exclusiveMinimum", "Value must be greater than %v.", min)
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && value >= max {
+		fail("exclusiveMaximum", "Value must be less than %v.", max)
+	}
+	if factor, ok := schema["multipleOf"].(float64); ok && factor > 0 {
+		quotient := value / factor
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			fail("multipleOf", "Value must be a multiple of %v.", factor)
+		}
+	}
+	return violations
+}
+
+// validateArray applies the array keywords and validates the items.
+func (s *Schema) validateArray(schema map[string]interface{}, value []interface{}, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	if min, ok := schema["minItems"].(float64); ok && float64(len(value)) < min {
+		fail("minItems", "Array must have at least %v items.", min)
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(value)) > max {
+		fail("maxItems", "Array must have at most %v items.", max)
+	}
+	if unique, ok := schema["uniqueItems"].(bool); ok && unique {
+	duplicates:
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					fail("uniqueItems", "Items %d and %d are equal.", i, j)
+					break duplicates
+				}
+			}
+		}
+	}
+
+	switch items := schema["items"].(type) {
+	case []interface{}:
+		for i, item := range value {
+			itemPointer := fmt.Sprintf("%s/%d", pointer, i)
+			if i < len(items) {
+				violations = append(violations, s.validate(items[i], item, itemPointer)...)
+			} else if additional, ok := schema["additionalItems"]; ok {
+				violations = append(violations, s.validate(additional, item, itemPointer)...)
+			}
+		}
+	case nil:
+	default:
+		for i, item := range value {
+			violations = append(violations, s.validate(items, item, fmt.Sprintf("%s/%d", pointer, i))...)
+		}
+	}
+
+	if contains, ok := schema["contains"]; ok {
+		found := false
+		for i, item := range value {
+			if len(s.validate(contains, item, fmt.Sprintf("%s/%d", pointer, i))) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("contains", "Array does not contain any matching item.")
+		}
+	}
+	return violations
+}
+
+// validateObject applies the object keywords and validates the properties.
+func (s *Schema) validateObject(schema map[string]interface{}, value map[string]interface{}, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := value[name]; !present {
+					violations = append(violations, Violation{
+						Pointer: pointer + "/" + escape(name),
+						Keyword: "required",
+						Message: "Property is required.",
+					})
+				}
+			}
+		}
+	}
+	if min, ok := schema["minProperties"].(float64); ok && float64(len(value)) < min {
+		fail("minProperties", "Object must have at least %v properties.", min)
+	}
+	if max, ok := schema["maxProperties"].(float64); ok && float64(len(value)) > max {
+		fail("maxProperties", "Object must have at most %v properties.", max)
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	patterns, _ := schema["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := schema["additionalProperties"]
+	names, hasNames := schema["propertyNames"]
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child := value[key]
+		childPointer := pointer + "/" + escape(key)
+		if hasNames && len(s.validate(names, key, childPointer)) > 0 {
+			violations = append(violations, Violation{
+				Pointer: childPointer,
+				Keyword: "propertyNames",
+				Message: fmt.Sprintf("Property name %q is not allowed.", key),
+				Params:  []interface{}{key},
+			})
+		}
+
+		matched := false
+		if property, ok := properties[key]; ok {
+			matched = true
+			violations = append(violations, s.validate(property, child, childPointer)...)
+		}
+		for pattern, property := range patterns {
+			if re, err := s.compile(pattern); err == nil && re.MatchString(key) {
+				matched = true
+				violations = append(violations, s.validate(property, child, childPointer)...)
+			}
+		}
+		if !matched && hasAdditional {
+			if allowed, ok := additional.(bool); ok && !allowed {
+				violations = append(violations, Violation{
+					Pointer: childPointer,
+					Keyword: "additionalProperties",
+					Message: "Property is not allowed.",
+				})
+			} else {
+				violations = append(violations, s.validate(additional, child, childPointer)...)
+			}
+		}
+	}
+	return violations
+}
+
+// validateCombinators applies allOf, anyOf, oneOf, not and if/then/else.
+func (s *Schema) validateCombinators(schema map[string]interface{}, value interface{}, pointer string) []Violation {
+	var violations []Violation
+	fail := func(keyword, format string, args ...interface{}) {
+		violations = append(violations, Violation{Pointer: pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...), Params: args})
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			violations = append(violations, s.validate(sub, value, pointer)...)
+		}
+	}
+	if any, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, sub := range any {
+			if len(s.validate(sub, value, pointer)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("anyOf", "Value does not match any of the allowed schemas.%s", describe(schema))
+		}
+	}
+	if one, ok := schema["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, sub := range one {
+			if len(s.validate(sub, value, pointer)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			fail("oneOf", "Value must match exactly one schema, it matches %d.%s", matches, describe(schema))
+		}
+	}
+	if not, ok := schema["not"]; ok && len(s.validate(not, value, pointer)) == 0 {
+		fail("not", "Value matches a forbidden schema.")
+	}
+	if cond, ok := schema["if"]; ok {
+		if len(s.validate(cond, value, pointer)) == 0 {
+			if then, ok := schema["then"]; ok {
+				violations = append(violations, s.validate(then, value, pointer)...)
+			}
+		} else if otherwise, ok := schema["else"]; ok {
+			violations = append(violations, s.validate(otherwise, value, pointer)...)
+		}
+	}
+	return violations
+}
+
+// matchesType reports whether value has the type (or one of the types) given by the type keyword.
+func matchesType(types interface{}, value interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return isType(t, value)
+	case []interface{}:
+		for _, name := range t {
+			if name, ok := name.(string); ok && isType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isType reports whether value is of the JSON Schema type name.
+func isType(name string, value interface{}) bool {
+	switch name {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	}
+	return typeOf(value) == name
+}
+
+// typeOf returns the JSON Schema type of a decoded value.
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// describeType renders the type keyword for messages.
+func describeType(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, 0, len(list))
+		for _, name := range list {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(types)
+}
+
+// Formats checked by the format keyword, unknown formats are accepted.
+var (
+	dateTimeFormat = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})$`)
+)
+
+// matchesFormat reports whether value is valid for format.
+func matchesFormat(format, value string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	case "date-time":
+		if !dateTimeFormat.MatchString(value) {
+			return false
+		}
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(value))
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	}
+	return true
+}
+
+// describe returns the description of a schema, prefixed with a space, to complete messages.
+func describe(schema map[string]interface{}) string {
+	if description, ok := schema["description"].(string); ok && description != "" {
+		return " " + description
+	}
+	return ""
+}
+
+// compact renders a schema value as compact JSON for messages.
+func compact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// escape escapes a reference token of a JSON pointer.
+func escape(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -42,134 +42,231 @@
 // =================================================================================================================================================
 // =================================================================================================================================================
 
-// Package leveldb provides some useful functions to work with LevelDB.
-// It has common database functions as OpenDB, CloseDB, Insert and Iterate.
+// Package leveldb implements the storage.BFTXStore interface with LevelDB.
+// The Store type keeps a single LevelDB handle, safe for concurrent use, for the lifetime of the application.
 package leveldb
 
 import (
 	// =======================
 	// Golang Standard library
 	// =======================
-	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
-	"errors"        // Implements functions to manipulate errors.
+	"errors"  // Implements functions to manipulate errors.
+	"sync"    // Provides basic synchronization primitives such as mutual exclusion locks.
+	"syscall" // Contains an interface to the low-level operating system primitives.
+	"time"    // Provides functionality for measuring and displaying time.
 
 	// ====================
 	// Third-party packages
 	// ====================
-	"github.com/syndtr/goleveldb/leveldb" // Implementation of the LevelDB key/value database in the Go programming language.
+	"github.com/syndtr/goleveldb/leveldb"      // Implementation of the LevelDB key/value database in the Go programming language.
+	"github.com/syndtr/goleveldb/leveldb/opt"  // Provides sets of options used by LevelDB.
+	"github.com/syndtr/goleveldb/leveldb/util" // Provides utilities used throughout LevelDB.
 
 	// ======================
 	// Blockfreight™ packages
 	// ======================
-	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/pkg/storage" // Defines the BFTXStore interface of the embedded stores.
 )
 
-var dbPath = "bft-db" //Folder name where is going to be the LevelDB
+// DefaultPath is the folder of the LevelDB when no path is configured.
+const DefaultPath = "bft-db"
 
-// OpenDB is a function that receives the path of the DB, creates or opens that DB and return ir with a possible error if that occurred.
-func OpenDB(dbPath string) (db *leveldb.DB, err error) {
-	db, err = leveldb.OpenFile(dbPath, nil)
-	return db, err
+// ErrLocked is returned when another process holds the DB for longer than the lock timeout.
+var ErrLocked = errors.New("LevelDB is locked by another process.")
 
+// Store struct
+type Store struct {
+	path  string
+	db    *leveldb.DB
+	write *opt.WriteOptions
+
+	// Operations hold the read lock, Close the write lock, so the handle is never closed under a running operation
+	mtx    sync.RWMutex
+	closed bool
 }
 
-// CloseDB is a function that receives a DB pointer that closes the connection to DB.
-func CloseDB(db *leveldb.DB) {
-	db.Close()
+func init() {
+	storage.Register("leveldb", func(path string, options *storage.Options) (storage.BFTXStore, error) {
+		return Open(path, options)
+	})
 }
 
-// InsertBFTX is a function that receives the key and value strings to insert a tuple in determined DB, the final parameter. As result, it returns a true or false bool.
-func InsertBFTX(key string, value string, db *leveldb.DB) error {
-	return db.Put([]byte(key), []byte(value), nil)
+// Open creates or opens the DB stored in path. A nil options uses the defaults.
+func Open(path string, options *storage.Options) (*Store, error) {
+	if options == nil {
+		options = &storage.Options{}
+	}
+	if path == "" {
+		path = DefaultPath
+	}
+	dbOptions := &opt.Options{
+		ReadOnly:           options.ReadOnly,
+		BlockCacheCapacity: options.CacheSize * opt.MiB,
+	}
+
+	deadline := time.Now().Add(options.LockTimeout)
+	for {
+		db, err := leveldb.OpenFile(path, dbOptions)
+		if err == nil {
+			return &Store{
+				path:  path,
+				db:    db,
+				write: &opt.WriteOptions{Sync: options.Sync},
+			}, nil
+		}
+		if !isLocked(err) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrLocked
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
 }
 
-// Total is a function that returns the total of BF_TX stored in the DB.
-func Total() (int, error) {
-	db, err := OpenDB(dbPath)
-	defer CloseDB(db)
-	if err != nil {
-		return 0, err
+// isLocked reports whether err comes from the file lock held by another process.
+func isLocked(err error) bool {
+	errno, ok := err.(syscall.Errno)
+	return ok && errno.Temporary()
+}
+
+// Path returns the folder of the DB.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Close closes the DB. It waits for the running operations, and later operations return storage.ErrClosed.
+func (s *Store) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.closed {
+		return nil
 	}
+	s.closed = true
+	return s.db.Close()
+}
 
-	iter := db.NewIterator(nil, nil)
-	n := 0
-	for iter.Next() {
-		n += 1
+// acquire takes the read lock, failing when the store is closed.
+func (s *Store) acquire() error {
+	s.mtx.RLock()
+	if s.closed {
+		s.mtx.RUnlock()
+		return storage.ErrClosed
 	}
-	iter.Release()
-	return n, iter.Error()
+	return nil
 }
 
-// RecordOnDB is a function that receives the content of the BF_RX JSON to insert it into the DB and return true or false according to the result.
-func RecordOnDB(id string, json string) error {
-	db, err := OpenDB(dbPath)
-	defer CloseDB(db)
-	if err != nil {
+func (s *Store) release() {
+	s.mtx.RUnlock()
+}
+
+// Put stores value under key.
+func (s *Store) Put(key, value []byte) error {
+	if err := s.acquire(); err != nil {
 		return err
 	}
-	err = InsertBFTX(id, json, db)
+	defer s.release()
+
+	return s.db.Put(key, value, s.write)
+}
+
+// Get returns the value stored under key.
+func (s *Store) Get(key []byte) ([]byte, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	value, err := s.db.Get(key, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, storage.ErrNotFound
+	}
 	if err != nil {
-		return err
+		return nil, errors.New("LevelDB Get function: " + err.Error())
 	}
-	return nil
+	return value, nil
 }
 
-// GetBfTx is a function that receives a bf_tx id, and returns the BF_TX if it exists.
-func GetBfTx(id string) (bf_tx.BF_TX, error) {
-	var bftx bf_tx.BF_TX
-	db, err := OpenDB(dbPath)
-	defer CloseDB(db)
-	if err != nil {
-		return bftx, err
+// Delete removes key.
+func (s *Store) Delete(key []byte) error {
+	if err := s.acquire(); err != nil {
+		return err
 	}
+	defer s.release()
 
-	data, err := db.Get([]byte(id), nil)
-	if err != nil {
-		if err.Error() == "leveldb: not found" {
-			return bftx, errors.New("LevelDB Get function: BF_TX not found.")
-		}
-		return bftx, errors.New("LevelDB Get function: " + err.Error())
+	return s.db.Delete(key, s.write)
+}
+
+// Has reports whether key is stored.
+func (s *Store) Has(key []byte) (bool, error) {
+	if err := s.acquire(); err != nil {
+		return false, err
 	}
+	defer s.release()
 
-	json.Unmarshal(data, &bftx)
-	return bftx, nil
+	return s.db.Has(key, nil)
 }
 
-// Verify is a function that receives a content and look for a BF_TX that has the same content.
-func Verify(jcontent string) ([]byte, error) {
-	var bftx bf_tx.BF_TX
-	db, err := OpenDB(dbPath)
-	defer CloseDB(db)
+// Iterate calls fn for every key starting with prefix, in ascending key order.
+func (s *Store) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	// Take the snapshot under the lock, so fn runs without it and may write to the store
+	if err := s.acquire(); err != nil {
+		return err
+	}
+	snapshot, err := s.db.GetSnapshot()
+	s.release()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer snapshot.Release()
 
-	iter := db.NewIterator(nil, nil)
+	iter := snapshot.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
 	for iter.Next() {
-		key := iter.Key()
-		value := iter.Value()
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			if err == storage.ErrStop {
+				return nil
+			}
+			return err
+		}
+	}
+	if err := iter.Error(); err == leveldb.ErrClosed {
+		return storage.ErrClosed
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
 
-		// Get a BF_TX by id
-		json.Unmarshal(value, &bftx)
+// Batch returns an empty batch.
+func (s *Store) Batch() storage.Batch {
+	return &batch{store: s}
+}
 
-		// Reinitialize the BF_TX
-		bftx = bf_tx.Reinitialize(bftx)
+type batch struct {
+	store *Store
+	batch leveldb.Batch
+}
 
-		// Get the BF_TX old_content in string format
-		content, err := bf_tx.BFTXContent(bftx)
-		if err != nil {
-			return nil, err
-		}
+func (b *batch) Put(key, value []byte) {
+	b.batch.Put(key, value)
+}
 
-		if jcontent == content {
-			iter.Release()
-			//strconv.Atoi(string(buf))
-			return key, nil
-		}
+func (b *batch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
+func (b *batch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *batch) Write() error {
+	if err := b.store.acquire(); err != nil {
+		return err
 	}
-	iter.Release()
+	defer b.store.release()
 
-	return nil, iter.Error()
+	return b.store.db.Write(&b.batch, b.store.write)
 }
 
 // =================================================
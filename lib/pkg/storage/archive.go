@@ -0,0 +1,345 @@
+// File: ./blockfreight/lib/pkg/storage/archive.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bufio"         // Implements buffered I/O.
+	"bytes"         // Implements functions for the manipulation of byte slices.
+	"crypto/sha256" // Implements the SHA224 and SHA256 hash algorithms as defined in FIPS 180-4.
+	"encoding/hex"  // Implements hexadecimal encoding and decoding.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"io"            // Provides basic interfaces to I/O primitives.
+	"strconv"       // Implements conversions to and from string representations of basic data types.
+	"time"          // Provides functionality for measuring and displaying time.
+)
+
+// An archive is NDJSON: a header object, one ["<base64 key>","<base64 value>"] array per entry of the store in key
+// order, and a trailer object with the number of entries and the SHA-256 of the entry lines.
+const (
+	ArchiveFormat  = "bftx-archive"
+	ArchiveVersion = 1
+)
+
+// Conflict policies of Import, for the BF_TX stored with another content
+const (
+	ConflictSkip      = "skip"      // Keep the stored BF_TX.
+	ConflictOverwrite = "overwrite" // Replace the stored BF_TX.
+	ConflictFail      = "fail"      // Import nothing.
+)
+
+var (
+	// ErrChecksum is returned by Import when the archive is truncated or was modified.
+	ErrChecksum = errors.New("The archive checksum does not match, it is truncated or corrupted.")
+	// ErrConflict is returned by Import with ConflictFail when a BF_TX is stored with another content.
+	ErrConflict = errors.New("The archive conflicts with the stored BF_TX.")
+)
+
+// ArchiveHeader struct
+type ArchiveHeader struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	Created string `json:"created"` // RFC 3339 time of the export.
+}
+
+// ArchiveTrailer struct
+type ArchiveTrailer struct {
+	Entries int    `json:"entries"` // Number of entries, BF_TX and index entries.
+	Records int    `json:"records"` // Number of BF_TX.
+	SHA256  string `json:"sha256"`  // Hex encoded SHA-256 of the entry lines.
+}
+
+// ImportResult struct
+type ImportResult struct {
+	Entries   int      // Entries read from the archive.
+	Imported  int      // BF_TX written.
+	Unchanged int      // BF_TX already stored with the same content.
+	Skipped   int      // BF_TX stored with another content and kept.
+	Conflicts []string // Ids of the BF_TX stored with another content.
+}
+
+// Export writes every entry of the store, BF_TX and indexes, to w. The entries come from a snapshot, so the
+// archive is consistent while the store keeps being written.
+func Export(s BFTXStore, w io.Writer) (ArchiveTrailer, error) {
+	var trailer ArchiveTrailer
+	out := bufio.NewWriter(w)
+	header, err := json.Marshal(ArchiveHeader{
+		Format:  ArchiveFormat,
+		Version: ArchiveVersion,
+		Created: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return trailer, err
+	}
+	out.Write(append(header, '\n'))
+
+	checksum := sha256.New()
+	err = s.Iterate(nil, func(key, value []byte) error {
+		line, err := json.Marshal([][]byte{key, value})
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+		checksum.Write(line)
+		if _, err := out.Write(line); err != nil {
+			return err
+		}
+		trailer.Entries++
+		if !IsIndexKey(key) {
+			trailer.Records++
+		}
+		return nil
+	})
+	if err != nil {
+		return trailer, err
+	}
+
+	trailer.SHA256 = hex.EncodeToString(checksum.Sum(nil))
+	line, err := json.Marshal(trailer)
+	if err != nil {
+		return trailer, err
+	}
+	out.Write(append(line, '\n'))
+	return trailer, out.Flush()
+}
+
+// entry is a key and its value read from an archive.
+type entry struct {
+	key, value []byte
+}
+
+// readArchive reads and checks a whole archive.
+func readArchive(r io.Reader) ([]entry, error) {
+	in := bufio.NewReader(r)
+	line, err := readLine(in)
+	if err != nil {
+		return nil, err
+	}
+	var header ArchiveHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Format != ArchiveFormat {
+		return nil, errors.New("Not a BF_TX archive.")
+	}
+	if header.Version != ArchiveVersion {
+		return nil, errors.New("Unsupported BF_TX archive version " + strconv.Itoa(header.Version) + ".")
+	}
+
+	var entries []entry
+	checksum := sha256.New()
+	for n := 2; ; n++ {
+		line, err := readLine(in)
+		if err == io.EOF {
+			// The trailer is missing
+			return nil, ErrChecksum
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.HasPrefix(line, []byte("[")) {
+			var trailer ArchiveTrailer
+			if err := json.Unmarshal(line, &trailer); err != nil {
+				return nil, errors.New("Invalid BF_TX archive line " + strconv.Itoa(n) + ": " + err.Error())
+			}
+			if trailer.Entries != len(entries) || trailer.SHA256 != hex.EncodeToString(checksum.Sum(nil)) {
+				return nil, ErrChecksum
+			}
+			return entries, nil
+		}
+
+		checksum.Write(line)
+		checksum.Write([]byte{'\n'})
+		var kv [][]byte
+		if err := json.Unmarshal(line, &kv); err != nil || len(kv) != 2 || len(kv[0]) == 0 {
+			return nil, errors.New("Invalid BF_TX archive line " + strconv.Itoa(n) + ".")
+		}
+		if kv[1] == nil {
+			kv[1] = []byte{}
+		}
+		entries = append(entries, entry{kv[0], kv[1]})
+	}
+}
+
+// readLine returns the next line without its line feed.
+func readLine(in *bufio.Reader) ([]byte, error) {
+	line, err := in.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	return bytes.TrimSuffix(line, []byte("\n")), err
+}
+
+// Import reads an archive written by Export and stores its BF_TX, in a single transaction. Into an empty store the
+// entries are restored as they are, indexes included. Otherwise the BF_TX are merged according to the conflict
+// policy and indexed again.
+func Import(s BFTXStore, r io.Reader, policy string) (ImportResult, error) {
+	var result ImportResult
+	if policy != ConflictSkip && policy != ConflictOverwrite && policy != ConflictFail {
+		return result, errors.New("Unknown conflict policy " + policy + ", use " + ConflictSkip + ", " + ConflictOverwrite + " or " + ConflictFail + ".")
+	}
+	entries, err := readArchive(r)
+	if err != nil {
+		return result, err
+	}
+	result.Entries = len(entries)
+
+	err = Update(s, func(tx *Tx) error {
+		empty := true
+		err := s.Iterate(nil, func(key, value []byte) error {
+			empty = false
+			return ErrStop
+		})
+		if err != nil {
+			return err
+		}
+		if empty {
+			for _, e := range entries {
+				tx.batch.Put(e.key, e.value)
+				if !IsIndexKey(e.key) {
+					result.Imported++
+				}
+			}
+			return nil
+		}
+
+		for _, e := range entries {
+			if IsIndexKey(e.key) {
+				continue
+			}
+			id := string(e.key)
+			bftx, err := decodeRecord(e.key, e.value)
+			if err != nil {
+				return err
+			}
+			content, err := json.Marshal(bftx)
+			if err != nil {
+				return err
+			}
+
+			// Records of other format versions are compared by their content
+			stored, err := tx.GetBfTx(id)
+			if err == nil {
+				if storedContent, err := json.Marshal(stored); err == nil && bytes.Equal(storedContent, content) {
+					result.Unchanged++
+					continue
+				}
+				result.Conflicts = append(result.Conflicts, id)
+				if policy != ConflictOverwrite {
+					result.Skipped++
+					continue
+				}
+			} else if err != ErrNotFound {
+				return err
+			}
+			if err := tx.PutBfTx(id, string(content)); err != nil {
+				return err
+			}
+			result.Imported++
+		}
+		if policy == ConflictFail && len(result.Conflicts) > 0 {
+			result.Imported, result.Skipped = 0, 0
+			return ErrConflict
+		}
+		return nil
+	})
+	return result, err
+}
+
+// Copy writes every entry of src, BF_TX and indexes, to the empty store dst in a single batch. The entries come
+// from a snapshot of src, so the copy is consistent while src keeps being written.
+func Copy(src, dst BFTXStore) (int, error) {
+	empty, err := isEmpty(dst)
+	if err != nil {
+		return 0, err
+	}
+	if !empty {
+		return 0, errors.New("The destination store is not empty.")
+	}
+
+	n := 0
+	batch := dst.Batch()
+	err = src.Iterate(nil, func(key, value []byte) error {
+		batch.Put(append([]byte{}, key...), append([]byte{}, value...))
+		if !IsIndexKey(key) {
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, batch.Write()
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
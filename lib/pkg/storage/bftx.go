@@ -0,0 +1,188 @@
+// File: ./blockfreight/lib/pkg/storage/bftx.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"errors"  // Implements functions to manipulate errors.
+	"reflect" // Implements run-time reflection, allowing a program to manipulate objects with arbitrary types.
+	"sync"    // Provides basic synchronization primitives such as mutual exclusion locks.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+// ErrAmended is returned by AppendBfTx when the BF_TX already has an amendment.
+var ErrAmended = errors.New("BF_TX already amended.")
+
+// ErrChanged is returned by ReplaceBfTx when the BF_TX changed since it was read.
+var ErrChanged = errors.New("BF_TX changed since it was read.")
+
+// writeMtx serializes the writes of BF_TX, which read the previous record to update the indexes.
+var writeMtx sync.Mutex
+
+// PutBfTx receives the id and the JSON content of a BF_TX and stores it, replacing the previous content.
+// The record and its index entries are written atomically.
+func PutBfTx(s BFTXStore, id string, json string) error {
+	return Update(s, func(tx *Tx) error {
+		return tx.PutBfTx(id, json)
+	})
+}
+
+// ReplaceBfTx stores the JSON content of the BF_TX old, read before, if the stored BF_TX is still old. Otherwise
+// nothing is written and it fails with ErrChanged. It lets the slow work on a BF_TX, such as prompting for a
+// passphrase or delivering it to the application, run outside of a transaction.
+func ReplaceBfTx(s BFTXStore, old bf_tx.BF_TX, json string) error {
+	return Update(s, func(tx *Tx) error {
+		bftx, err := tx.GetBfTx(old.Id)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(bftx, old) {
+			return ErrChanged
+		}
+		return tx.PutBfTx(old.Id, json)
+	})
+}
+
+// GetBfTx receives a BF_TX id, and returns the BF_TX if it exists.
+func GetBfTx(s BFTXStore, id string) (bf_tx.BF_TX, error) {
+	if IsIndexKey([]byte(id)) {
+		return bf_tx.BF_TX{}, ErrNotFound
+	}
+	value, err := s.Get([]byte(id))
+	if err != nil {
+		return bf_tx.BF_TX{}, err
+	}
+	return decodeRecord([]byte(id), value)
+}
+
+// IterateBfTx calls fn with the id and the stored record of every BF_TX, in ascending id order.
+func IterateBfTx(s BFTXStore, fn func(id, value []byte) error) error {
+	return s.Iterate(nil, func(key, value []byte) error {
+		if IsIndexKey(key) {
+			return nil
+		}
+		return fn(key, value)
+	})
+}
+
+// Total returns the total of BF_TX stored.
+func Total(s BFTXStore) (int, error) {
+	n := 0
+	err := IterateBfTx(s, func(id, json []byte) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// ConstructBfTx stores a new BF_TX under an id derived from its content and salt, the chain state, and returns
+// the id.
+func ConstructBfTx(s BFTXStore, bftx bf_tx.BF_TX, salt []byte) (string, error) {
+	err := Update(s, func(tx *Tx) error {
+		return tx.CreateBfTx(&bftx, salt)
+	})
+	return bftx.Id, err
+}
+
+// AppendBfTx stores the amendment of the BF_TX id under a new id, as ConstructBfTx, and links the BF_TX to it,
+// together. A BF_TX is amended once: appending to an amended BF_TX fails with ErrAmended.
+func AppendBfTx(s BFTXStore, id string, amendment bf_tx.BF_TX, salt []byte) (string, error) {
+	err := Update(s, func(tx *Tx) error {
+		old, err := tx.GetBfTx(id)
+		if err != nil {
+			return err
+		}
+		if old.Amendment != "" {
+			return ErrAmended
+		}
+		if err := tx.CreateBfTx(&amendment, salt); err != nil {
+			return err
+		}
+
+		// Update the BF_TX appended attribute of the old BF_TX
+		old.Amendment = amendment.Id
+		content, err := bf_tx.BFTXContent(old)
+		if err != nil {
+			return err
+		}
+		return tx.PutBfTx(id, content)
+	})
+	return amendment.Id, err
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,281 @@
+// File: ./blockfreight/lib/pkg/storage/index.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"         // Implements functions for the manipulation of byte slices.
+	"crypto/sha256" // Implements the SHA224 and SHA256 hash algorithms as defined in FIPS 180-4.
+	"encoding/hex"  // Implements hexadecimal encoding and decoding.
+	"errors"        // Implements functions to manipulate errors.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+// The index keys share the keyspace with the BF_TX records, under a prefix which is never a BF_TX id.
+// The content index maps the SHA-256 of the canonical content of a BF_TX to its id: idx/content/<hash>/<id>.
+// The field indexes map the searchable fields to the ids: idx/field/<field>/<value>\x00<id>.
+var (
+	indexPrefix        = []byte("idx/")
+	contentIndexPrefix = "idx/content/"
+	fieldIndexPrefix   = "idx/field/"
+	contentIndexReady  = []byte("idx/meta/content")
+	fieldIndexReady    = []byte("idx/meta/fields")
+)
+
+// ErrIndexMissing is returned by Verify and Search on a DB written before their index existed.
+var ErrIndexMissing = errors.New("The content index is missing, run bftx rebuild-index.")
+
+// IsIndexKey reports whether key belongs to an index instead of a BF_TX record.
+func IsIndexKey(key []byte) bool {
+	return bytes.HasPrefix(key, indexPrefix)
+}
+
+// ContentHash returns the hex encoded SHA-256 of the canonical content of a BF_TX.
+func ContentHash(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func contentKey(hash, id string) []byte {
+	return []byte(contentIndexPrefix + hash + "/" + id)
+}
+
+// indexKeys returns the index entries of a BF_TX.
+func indexKeys(id string, bftx bf_tx.BF_TX) ([][]byte, error) {
+	content, err := bf_tx.CanonicalContent(bftx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := [][]byte{contentKey(ContentHash(content), id)}
+	for _, field := range Fields {
+		if value := normalize(fieldValue(bftx, field)); value != "" {
+			keys = append(keys, fieldKey(field, value, id))
+		}
+	}
+	return keys, nil
+}
+
+// stageBfTx adds to batch the writes which store the JSON content of a BF_TX and update its index entries, and
+// returns the record written. old is the record the BF_TX had before, nil for a new BF_TX.
+func stageBfTx(s BFTXStore, batch Batch, id string, json string, old []byte) ([]byte, error) {
+	bftx, err := decodeStrict([]byte(json))
+	if err != nil {
+		return nil, errors.New("BF_TX " + id + ": " + err.Error())
+	}
+	value, err := encodeRecord([]byte(json))
+	if err != nil {
+		return nil, err
+	}
+	keys, err := indexKeys(id, bftx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the entries of the previous content
+	if old != nil {
+		if oldBftx, err := decodeRecord([]byte(id), old); err == nil {
+			if oldKeys, err := indexKeys(id, oldBftx); err == nil {
+				current := map[string]bool{}
+				for _, key := range keys {
+					current[string(key)] = true
+				}
+				for _, key := range oldKeys {
+					if !current[string(key)] {
+						batch.Delete(key)
+					}
+				}
+			}
+		}
+	}
+
+	// The first BF_TX of a new DB marks its indexes as complete
+	ready, err := indexReady(s)
+	if err != nil {
+		return nil, err
+	}
+	if !ready {
+		empty, err := isEmpty(s)
+		if err != nil {
+			return nil, err
+		}
+		if empty {
+			batch.Put(contentIndexReady, []byte{})
+			batch.Put(fieldIndexReady, []byte{})
+		}
+	}
+
+	batch.Put([]byte(id), value)
+	for _, key := range keys {
+		batch.Put(key, []byte{})
+	}
+	return value, nil
+}
+
+// indexReady reports whether every index is complete.
+func indexReady(s BFTXStore) (bool, error) {
+	for _, marker := range [][]byte{contentIndexReady, fieldIndexReady} {
+		if ok, err := s.Has(marker); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// requireIndex reports whether the index of marker can be used. It fails with ErrIndexMissing when the index was
+// never built for the stored BF_TX.
+func requireIndex(s BFTXStore, marker []byte) (bool, error) {
+	ready, err := s.Has(marker)
+	if err != nil || ready {
+		return ready, err
+	}
+	empty, err := isEmpty(s)
+	if err != nil || empty {
+		return false, err
+	}
+	return false, ErrIndexMissing
+}
+
+// isEmpty reports whether the store has no BF_TX.
+func isEmpty(s BFTXStore) (bool, error) {
+	empty := true
+	err := IterateBfTx(s, func(id, json []byte) error {
+		empty = false
+		return ErrStop
+	})
+	return empty, err
+}
+
+// Verify receives the canonical content of a Bill of Lading and looks up the BF_TX that has the same content in
+// the content index. It returns the id of the BF_TX, or nil when there is none.
+func Verify(s BFTXStore, content []byte) ([]byte, error) {
+	if ok, err := requireIndex(s, contentIndexReady); !ok {
+		return nil, err
+	}
+
+	var id []byte
+	prefix := []byte(contentIndexPrefix + ContentHash(content) + "/")
+	err := s.Iterate(prefix, func(key, value []byte) error {
+		id = append([]byte{}, key[len(prefix):]...)
+		return ErrStop
+	})
+	return id, err
+}
+
+// RebuildIndex drops the indexes and indexes again every BF_TX, in a single batch.
+// It returns the number of BF_TX indexed.
+func RebuildIndex(s BFTXStore) (int, error) {
+	writeMtx.Lock()
+	defer writeMtx.Unlock()
+
+	batch := s.Batch()
+	for _, prefix := range []string{contentIndexPrefix, fieldIndexPrefix} {
+		err := s.Iterate([]byte(prefix), func(key, value []byte) error {
+			batch.Delete(append([]byte{}, key...))
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := 0
+	err := IterateBfTx(s, func(id, value []byte) error {
+		bftx, err := decodeRecord(id, value)
+		if err != nil {
+			return err
+		}
+		keys, err := indexKeys(string(id), bftx)
+		if err != nil {
+			return errors.New("BF_TX " + string(id) + ": " + err.Error())
+		}
+		for _, key := range keys {
+			batch.Put(key, []byte{})
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	batch.Put(contentIndexReady, []byte{})
+	batch.Put(fieldIndexReady, []byte{})
+	return n, batch.Write()
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,265 @@
+// File: ./blockfreight/lib/pkg/storage/list.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"encoding/base64" // Implements base64 encoding as specified by RFC 4648.
+	"errors"          // Implements functions to manipulate errors.
+	"sort"            // Provides primitives for sorting slices and user-defined collections.
+	"strings"         // Implements simple functions to manipulate strings.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+// Sort orders of List
+const (
+	SortId   = "id"
+	SortDate = "date"
+	SortBol  = "bol"
+)
+
+// ErrInvalidCursor is returned by List when the cursor was not returned by a List with the same sort order.
+var ErrInvalidCursor = errors.New("Invalid cursor.")
+
+// Summary struct holds the fields of a BF_TX shown by List.
+type Summary struct {
+	Id          string `json:"id"`
+	BolNum      string `json:"bol_num"`
+	State       string `json:"state"`
+	Shipper     string `json:"shipper"`
+	DateShipped string `json:"date_shipped"`
+	Amendment   string `json:"amendment,omitempty"` // Id of the BF_TX which amends this one.
+}
+
+// ListOptions struct
+type ListOptions struct {
+	State  string // Only the BF_TX in this state, empty for all.
+	Sort   string // SortId, SortDate or SortBol. Ties are ordered by id.
+	Offset int    // Number of summaries skipped, ignored with a cursor.
+	Limit  int    // Maximum number of summaries, 0 for all.
+	Cursor string // Next cursor returned by the previous page.
+}
+
+// NewSummary returns the summary of a BF_TX.
+func NewSummary(bftx bf_tx.BF_TX) Summary {
+	return Summary{
+		Id:          bftx.Id,
+		BolNum:      bftx.Properties.BolNum,
+		State:       StateName(bftx),
+		Shipper:     bftx.Properties.Shipper.Name,
+		DateShipped: bftx.Properties.DateShipped,
+		Amendment:   bftx.Amendment,
+	}
+}
+
+// sortKey returns the value a summary is ordered by.
+func (summary Summary) sortKey(order string) string {
+	switch order {
+	case SortDate:
+		return summary.DateShipped
+	case SortBol:
+		return summary.BolNum
+	}
+	return summary.Id
+}
+
+// cursor identifies the position after a summary. It is opaque to the callers.
+type cursor struct {
+	order, key, id string
+}
+
+func (c cursor) encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.order + "\x00" + c.key + "\x00" + c.id))
+}
+
+func decodeCursor(token, order string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(data), "\x00")
+	if len(parts) != 3 || parts[0] != order {
+		return cursor{}, ErrInvalidCursor
+	}
+	return cursor{parts[0], parts[1], parts[2]}, nil
+}
+
+// before reports whether a summary is at or before the cursor.
+func (c cursor) before(summary Summary) bool {
+	key := summary.sortKey(c.order)
+	return key < c.key || key == c.key && summary.Id <= c.id
+}
+
+// List calls fn with the summary of every BF_TX selected by options, in order. The BF_TX ordered by id are streamed
+// from the store, the other orders load the summaries first. It returns the cursor of the next page, or an empty
+// string after the last page.
+func List(s BFTXStore, options ListOptions, fn func(Summary) error) (string, error) {
+	order := options.Sort
+	if order == "" {
+		order = SortId
+	}
+	if order != SortId && order != SortDate && order != SortBol {
+		return "", errors.New("Unknown sort order " + order + ", use " + SortId + ", " + SortDate + " or " + SortBol + ".")
+	}
+	var after *cursor
+	if options.Cursor != "" {
+		c, err := decodeCursor(options.Cursor, order)
+		if err != nil {
+			return "", err
+		}
+		after = &c
+	}
+	state := strings.ToLower(options.State)
+
+	// Page the summaries in order
+	skip := options.Offset
+	if after != nil {
+		skip = 0
+	}
+	var last Summary
+	emitted, next := 0, ""
+	page := func(summary Summary) error {
+		if state != "" && summary.State != state || after != nil && after.before(summary) {
+			return nil
+		}
+		if skip > 0 {
+			skip--
+			return nil
+		}
+		if options.Limit > 0 && emitted == options.Limit {
+			// A summary follows the page
+			next = cursor{order, last.sortKey(order), last.Id}.encode()
+			return ErrStop
+		}
+		if err := fn(summary); err != nil {
+			return err
+		}
+		last = summary
+		emitted++
+		return nil
+	}
+
+	if order == SortId {
+		err := IterateBfTx(s, func(id, json []byte) error {
+			summary, err := decodeSummary(id, json)
+			if err != nil {
+				return err
+			}
+			return page(summary)
+		})
+		return next, err
+	}
+
+	var summaries []Summary
+	err := IterateBfTx(s, func(id, json []byte) error {
+		summary, err := decodeSummary(id, json)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, summary)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].sortKey(order), summaries[j].sortKey(order)
+		return a < b || a == b && summaries[i].Id < summaries[j].Id
+	})
+	for _, summary := range summaries {
+		if err := page(summary); err == ErrStop {
+			break
+		} else if err != nil {
+			return "", err
+		}
+	}
+	return next, nil
+}
+
+// decodeSummary returns the summary of a stored BF_TX. The key of the record is its id.
+func decodeSummary(id, value []byte) (Summary, error) {
+	bftx, err := decodeRecord(id, value)
+	if err != nil {
+		return Summary{}, err
+	}
+	summary := NewSummary(bftx)
+	summary.Id = string(id)
+	return summary, nil
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
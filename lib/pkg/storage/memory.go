@@ -0,0 +1,235 @@
+// File: ./blockfreight/lib/pkg/storage/memory.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes" // Implements functions for the manipulation of byte slices.
+	"sort"  // Provides primitives for sorting slices and user-defined collections.
+	"sync"  // Provides basic synchronization primitives such as mutual exclusion locks.
+)
+
+// Memory is a BFTXStore which keeps the data in memory, for tests and short-lived tools.
+type Memory struct {
+	mtx    sync.RWMutex
+	data   map[string][]byte
+	closed bool
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{data: map[string][]byte{}}
+}
+
+// Put stores a copy of value under key.
+func (m *Memory) Put(key, value []byte) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.data[string(key)] = clone(value)
+	return nil
+}
+
+// Get returns a copy of the value stored under key.
+func (m *Memory) Get(key []byte) ([]byte, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if m.closed {
+		return nil, ErrClosed
+	}
+	value, ok := m.data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(value), nil
+}
+
+// Delete removes key.
+func (m *Memory) Delete(key []byte) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	delete(m.data, string(key))
+	return nil
+}
+
+// Has reports whether key is stored.
+func (m *Memory) Has(key []byte) (bool, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if m.closed {
+		return false, ErrClosed
+	}
+	_, ok := m.data[string(key)]
+	return ok, nil
+}
+
+// Iterate calls fn for every key starting with prefix, in ascending key order.
+func (m *Memory) Iterate(prefix []byte, fn func(key, value []byte) error) error {
+	// Take the snapshot, so fn runs without the lock
+	m.mtx.RLock()
+	if m.closed {
+		m.mtx.RUnlock()
+		return ErrClosed
+	}
+	keys := make([]string, 0, len(m.data))
+	for key := range m.data {
+		if bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		values[key] = m.data[key]
+	}
+	m.mtx.RUnlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := fn([]byte(key), clone(values[key])); err != nil {
+			if err == ErrStop {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Batch returns an empty batch.
+func (m *Memory) Batch() Batch {
+	return &memoryBatch{store: m}
+}
+
+// Close releases the data.
+func (m *Memory) Close() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.closed = true
+	m.data = nil
+	return nil
+}
+
+// memoryWrite is a Put, or a Delete when value is nil.
+type memoryWrite struct {
+	key   string
+	value []byte
+}
+
+type memoryBatch struct {
+	store  *Memory
+	writes []memoryWrite
+}
+
+func (b *memoryBatch) Put(key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	b.writes = append(b.writes, memoryWrite{string(key), clone(value)})
+}
+
+func (b *memoryBatch) Delete(key []byte) {
+	b.writes = append(b.writes, memoryWrite{key: string(key)})
+}
+
+func (b *memoryBatch) Len() int {
+	return len(b.writes)
+}
+
+func (b *memoryBatch) Write() error {
+	b.store.mtx.Lock()
+	defer b.store.mtx.Unlock()
+	if b.store.closed {
+		return ErrClosed
+	}
+	for _, w := range b.writes {
+		if w.value == nil {
+			delete(b.store.data, w.key)
+		} else {
+			b.store.data[w.key] = w.value
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of data which is never nil.
+func clone(data []byte) []byte {
+	return append([]byte{}, data...)
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
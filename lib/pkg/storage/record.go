@@ -0,0 +1,310 @@
+// File: ./blockfreight/lib/pkg/storage/record.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"         // Implements functions for the manipulation of byte slices.
+	"encoding/json" // Implements encoding and decoding of JSON as defined in RFC 4627.
+	"errors"        // Implements functions to manipulate errors.
+	"sort"          // Provides primitives for sorting slices and user-defined collections.
+	"strconv"       // Implements conversions to and from string representations of basic data types.
+	"sync"          // Provides basic synchronization primitives such as mutual exclusion locks.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"  // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto" // Provides useful functions to sign BF_TX.
+)
+
+// RecordVersion is the storage format version of the records written by this version of bftx.
+// The records written before the versions existed have none: version 0 is the legacy placeholder BF_TX and
+// version 1 the typed Bill of Lading.
+const RecordVersion = 2
+
+// record struct is the stored form of a BF_TX.
+type record struct {
+	Version int             `json:"version"`
+	BFTX    json.RawMessage `json:"bftx"`
+}
+
+// Migration struct upgrades the BF_TX JSON of a record from version From to From+1.
+type Migration struct {
+	From        int
+	Description string
+	Migrate     func(data []byte) ([]byte, error)
+}
+
+var (
+	migrationsMtx sync.RWMutex
+	migrations    = map[int]Migration{}
+)
+
+// RegisterMigration adds the migration from a record version, replacing the previous one.
+func RegisterMigration(migration Migration) {
+	migrationsMtx.Lock()
+	defer migrationsMtx.Unlock()
+	migrations[migration.From] = migration
+}
+
+// Migrations returns the registered migrations, by version.
+func Migrations() []Migration {
+	migrationsMtx.RLock()
+	defer migrationsMtx.RUnlock()
+	list := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		list = append(list, migration)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].From < list[j].From })
+	return list
+}
+
+func init() {
+	RegisterMigration(Migration{
+		From:        0,
+		Description: "Convert the legacy placeholder properties to the typed Bill of Lading",
+		Migrate: func(data []byte) ([]byte, error) {
+			bftx, err := bf_tx.ConvertLegacy(data)
+			if err != nil {
+				return nil, err
+			}
+			// The legacy signature is checked on the legacy content, which the conversion changes
+			if bftx.Signature != "" {
+				_, err = crypto.VerifyLegacy(data)
+				bftx.Verified = err == nil
+			}
+			return json.Marshal(bftx)
+		},
+	})
+	RegisterMigration(Migration{
+		From:        1,
+		Description: "Tag the record with its storage format version",
+		Migrate: func(data []byte) ([]byte, error) {
+			return data, nil
+		},
+	})
+}
+
+// encodeRecord returns the stored form of the JSON content of a BF_TX.
+func encodeRecord(content []byte) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, content); err != nil {
+		return nil, errors.New("Invalid BF_TX JSON: " + err.Error())
+	}
+	return json.Marshal(record{RecordVersion, compact.Bytes()})
+}
+
+// parseRecord returns the format version and the BF_TX JSON of a stored value.
+func parseRecord(value []byte) (int, []byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(value, &fields); err != nil {
+		return 0, nil, errors.New("Invalid record: " + err.Error())
+	}
+	if _, ok := fields["bftx"]; ok {
+		var r record
+		if err := json.Unmarshal(value, &r); err != nil {
+			return 0, nil, errors.New("Invalid record: " + err.Error())
+		}
+		return r.Version, r.BFTX, nil
+	}
+	if bf_tx.IsLegacy(value) {
+		return 0, value, nil
+	}
+	return 1, value, nil
+}
+
+// upgrade runs the migrations of the BF_TX JSON of a record from version up to RecordVersion.
+func upgrade(version int, data []byte) ([]byte, error) {
+	if version > RecordVersion {
+		return nil, errors.New("Record version " + strconv.Itoa(version) + " is newer than this bftx, which reads up to version " + strconv.Itoa(RecordVersion) + ".")
+	}
+	for ; version < RecordVersion; version++ {
+		migrationsMtx.RLock()
+		migration, ok := migrations[version]
+		migrationsMtx.RUnlock()
+		if !ok {
+			return nil, errors.New("No migration from record version " + strconv.Itoa(version) + ".")
+		}
+		var err error
+		if data, err = migration.Migrate(data); err != nil {
+			return nil, errors.New("Migration from record version " + strconv.Itoa(version) + ": " + err.Error())
+		}
+	}
+	return data, nil
+}
+
+// decodeRecord returns the BF_TX of a stored value, migrated to the current version. Fields unknown to the BF_TX
+// structure are reported instead of dropped.
+func decodeRecord(id, value []byte) (bf_tx.BF_TX, error) {
+	var bftx bf_tx.BF_TX
+	version, data, err := parseRecord(value)
+	if err == nil {
+		data, err = upgrade(version, data)
+	}
+	if err == nil {
+		bftx, err = decodeStrict(data)
+	}
+	if err != nil {
+		return bftx, errors.New("BF_TX " + string(id) + ": " + err.Error())
+	}
+	return bftx, nil
+}
+
+// decodeStrict returns the BF_TX of its JSON content, failing on the fields unknown to the BF_TX structure.
+func decodeStrict(data []byte) (bf_tx.BF_TX, error) {
+	var bftx bf_tx.BF_TX
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&bftx)
+	return bftx, err
+}
+
+// ErrMigrationFailed is returned by Migrate when some records cannot be migrated.
+var ErrMigrationFailed = errors.New("Some BF_TX cannot be migrated, nothing was written.")
+
+// MigrationFailure struct
+type MigrationFailure struct {
+	Id    string
+	Error string
+}
+
+// MigrationResult struct
+type MigrationResult struct {
+	Total     int         // BF_TX stored.
+	Current   int         // BF_TX already at RecordVersion.
+	Migrated  int         // BF_TX migrated, or to migrate in a dry run.
+	ByVersion map[int]int // BF_TX to migrate, by record version.
+	Failures  []MigrationFailure
+	Reindexed bool // The indexes were missing and have been built.
+}
+
+// Migrate upgrades every record to RecordVersion and indexes them again, in a single transaction. A dry run
+// reports the records to migrate and the failures, without writing. When a record fails, nothing is written.
+// The indexes of a DB written before they existed are built after the migration.
+func Migrate(s BFTXStore, dryRun bool) (MigrationResult, error) {
+	result := MigrationResult{ByVersion: map[int]int{}}
+	err := Update(s, func(tx *Tx) error {
+		type upgraded struct {
+			id      string
+			content []byte
+		}
+		var pending []upgraded
+		err := IterateBfTx(s, func(id, value []byte) error {
+			result.Total++
+			version, _, err := parseRecord(value)
+			if err == nil && version == RecordVersion {
+				result.Current++
+				return nil
+			}
+			bftx, err := decodeRecord(id, value)
+			if err != nil {
+				result.Failures = append(result.Failures, MigrationFailure{string(id), err.Error()})
+				return nil
+			}
+			content, err := json.Marshal(bftx)
+			if err != nil {
+				return err
+			}
+			result.ByVersion[version]++
+			result.Migrated++
+			pending = append(pending, upgraded{string(id), content})
+			return nil
+		})
+		if err != nil || dryRun {
+			return err
+		}
+		if len(result.Failures) > 0 {
+			return ErrMigrationFailed
+		}
+		for _, u := range pending {
+			if err := tx.PutBfTx(u.id, string(u.content)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil || dryRun {
+		return result, err
+	}
+
+	ready, err := indexReady(s)
+	if err == nil && !ready {
+		_, err = RebuildIndex(s)
+		result.Reindexed = err == nil
+	}
+	return result, err
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,301 @@
+// File: ./blockfreight/lib/pkg/storage/search.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"   // Implements functions for the manipulation of byte slices.
+	"errors"  // Implements functions to manipulate errors.
+	"sort"    // Provides primitives for sorting slices and user-defined collections.
+	"strings" // Implements simple functions to manipulate strings.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+// Fields are the names of the indexed BF_TX fields, which can be searched.
+var Fields = []string{"shipper", "consignee", "vessel", "pol", "pod", "bol", "shipped", "state"}
+
+// Operators of a Condition
+const (
+	OpEqual  = "eq"     // The field is Value.
+	OpPrefix = "prefix" // The field starts with Value.
+	OpRange  = "range"  // The field is between From and To, both included.
+)
+
+// Condition struct
+type Condition struct {
+	Field string
+	Op    string
+	Value string // Value of OpEqual and OpPrefix.
+	From  string // Lower bound of OpRange, empty for none.
+	To    string // Upper bound of OpRange, empty for none.
+}
+
+// Query struct. The BF_TX must match all the conditions, a query without conditions matches every BF_TX.
+type Query struct {
+	Conditions []Condition
+	Offset     int // Number of matching ids skipped.
+	Limit      int // Maximum number of ids returned, 0 for all.
+}
+
+// SearchResult struct holds a page of the ids matching a query, in ascending order.
+type SearchResult struct {
+	Ids    []string `json:"ids"`
+	Total  int      `json:"total"` // Number of BF_TX matching the query, without pagination.
+	Offset int      `json:"offset"`
+	Limit  int      `json:"limit"`
+}
+
+// fieldValue returns the value of an indexed field of a BF_TX.
+func fieldValue(bftx bf_tx.BF_TX, field string) string {
+	p := bftx.Properties
+	switch field {
+	case "shipper":
+		return p.Shipper.Name
+	case "consignee":
+		return p.Consignee.Name
+	case "vessel":
+		return p.Vessel.Name
+	case "pol":
+		return portValue(p.PortOfLoading)
+	case "pod":
+		return portValue(p.PortOfDischarge)
+	case "bol":
+		return p.BolNum
+	case "shipped":
+		return p.DateShipped
+	case "state":
+		return StateName(bftx)
+	}
+	return ""
+}
+
+// StateName returns the state of a BF_TX as searched and listed: constructed, signed or transmitted.
+func StateName(bftx bf_tx.BF_TX) string {
+	return strings.ToLower(strings.TrimSuffix(bf_tx.State(bftx), "!"))
+}
+
+// portValue identifies a port by its UN/LOCODE, or by its name when it has none.
+func portValue(port bf_tx.Port) string {
+	if port.Locode != "" {
+		return port.Locode
+	}
+	return port.Name
+}
+
+// normalize makes the search case insensitive. The NUL byte separates the value from the id in the index keys.
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Replace(value, "\x00", "", -1)))
+}
+
+func fieldKey(field, value, id string) []byte {
+	return []byte(fieldIndexPrefix + field + "/" + value + "\x00" + id)
+}
+
+func isField(field string) bool {
+	for _, f := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCondition parses a condition written field=value, field=prefix* or field=from..to, where from or to can be
+// omitted.
+func ParseCondition(expr string) (Condition, error) {
+	i := strings.Index(expr, "=")
+	if i <= 0 {
+		return Condition{}, errors.New("Invalid search condition " + expr + ", use field=value, field=prefix* or field=from..to.")
+	}
+	condition := Condition{Field: strings.ToLower(expr[:i]), Op: OpEqual, Value: expr[i+1:]}
+	if !isField(condition.Field) {
+		return Condition{}, errors.New("Unknown search field " + condition.Field + ", use one of: " + strings.Join(Fields, ", ") + ".")
+	}
+	if bounds := strings.SplitN(condition.Value, "..", 2); len(bounds) == 2 {
+		condition.Op, condition.Value, condition.From, condition.To = OpRange, "", bounds[0], bounds[1]
+	} else if strings.HasSuffix(condition.Value, "*") {
+		condition.Op, condition.Value = OpPrefix, strings.TrimSuffix(condition.Value, "*")
+	}
+	return condition, nil
+}
+
+// Search returns the ids of the BF_TX matching the query, looked up in the field indexes.
+func Search(s BFTXStore, query Query) (SearchResult, error) {
+	result := SearchResult{Ids: []string{}, Offset: query.Offset, Limit: query.Limit}
+	if ok, err := requireIndex(s, fieldIndexReady); !ok {
+		return result, err
+	}
+
+	var ids []string
+	if len(query.Conditions) == 0 {
+		err := IterateBfTx(s, func(id, json []byte) error {
+			ids = append(ids, string(id))
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+	} else {
+		// Intersect the ids matching each condition
+		var matches map[string]bool
+		for _, condition := range query.Conditions {
+			found, err := match(s, condition)
+			if err != nil {
+				return result, err
+			}
+			if matches != nil {
+				for id := range matches {
+					if !found[id] {
+						delete(matches, id)
+					}
+				}
+			} else {
+				matches = found
+			}
+		}
+		for id := range matches {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+
+	// Paginate
+	result.Total = len(ids)
+	if query.Offset >= len(ids) || query.Offset < 0 {
+		return result, nil
+	}
+	ids = ids[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(ids) {
+		ids = ids[:query.Limit]
+	}
+	result.Ids = ids
+	return result, nil
+}
+
+// match returns the ids of the BF_TX matching a condition.
+func match(s BFTXStore, condition Condition) (map[string]bool, error) {
+	if !isField(condition.Field) {
+		return nil, errors.New("Unknown search field " + condition.Field + ", use one of: " + strings.Join(Fields, ", ") + ".")
+	}
+	base := fieldIndexPrefix + condition.Field + "/"
+
+	var prefix, lower, upper []byte
+	switch condition.Op {
+	case OpEqual:
+		prefix = []byte(base + normalize(condition.Value) + "\x00")
+	case OpPrefix:
+		prefix = []byte(base + normalize(condition.Value))
+	case OpRange:
+		from, to := normalize(condition.From), normalize(condition.To)
+		lower = []byte(base + from)
+		if to != "" {
+			// The keys of the value to sort before to+"\x01", which sorts before any longer value
+			upper = []byte(base + to + "\x01")
+		}
+		// Scan only the values sharing the prefix of both bounds
+		prefix = []byte(base)
+		if from != "" && to != "" {
+			prefix = []byte(base + commonPrefix(from, to))
+		}
+	default:
+		return nil, errors.New("Unknown search operator " + condition.Op + ".")
+	}
+
+	ids := map[string]bool{}
+	err := s.Iterate(prefix, func(key, value []byte) error {
+		if lower != nil && bytes.Compare(key, lower) < 0 {
+			return nil
+		}
+		if upper != nil && bytes.Compare(key, upper) >= 0 {
+			return ErrStop
+		}
+		ids[string(key[bytes.LastIndexByte(key, 0)+1:])] = true
+		return nil
+	})
+	return ids, err
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,182 @@
+// File: ./blockfreight/lib/pkg/storage/storage.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package storage defines the BFTXStore interface implemented by the embedded key/value stores of the BF_TX, and
+// provides the in-memory implementation and some useful functions to work with the BF_TX stored in any of them.
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"errors" // Implements functions to manipulate errors.
+	"sort"   // Provides primitives for sorting slices and user-defined collections.
+	"sync"   // Provides basic synchronization primitives such as mutual exclusion locks.
+	"time"   // Provides functionality for measuring and displaying time.
+)
+
+// DefaultBackend is the store used when no backend is configured.
+const DefaultBackend = "leveldb"
+
+var (
+	// ErrNotFound is returned when the key is not stored.
+	ErrNotFound = errors.New("Key not found.")
+	// ErrClosed is returned when the store is used after Close.
+	ErrClosed = errors.New("Store is closed.")
+	// ErrStop is returned by an Iterate callback to end the iteration early, without error.
+	ErrStop = errors.New("Stop the iteration.")
+)
+
+// BFTXStore is implemented by the embedded key/value stores. Implementations are safe for concurrent use.
+type BFTXStore interface {
+	// Put stores value under key, replacing the previous value.
+	Put(key, value []byte) error
+	// Get returns the value stored under key, or ErrNotFound.
+	Get(key []byte) ([]byte, error)
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(key []byte) error
+	// Has reports whether key is stored.
+	Has(key []byte) (bool, error)
+	// Iterate calls fn for every key starting with prefix, in ascending key order, over a snapshot of the store taken
+	// when Iterate is called. fn must copy key and value to keep them, and may write to the store.
+	Iterate(prefix []byte, fn func(key, value []byte) error) error
+	// Batch returns an empty batch of writes applied atomically by its Write method.
+	Batch() Batch
+	// Close releases the store. Later operations return ErrClosed.
+	Close() error
+}
+
+// Batch collects writes which are applied all together or not at all.
+type Batch interface {
+	Put(key, value []byte)
+	Delete(key []byte)
+	// Len returns the number of writes in the batch.
+	Len() int
+	// Write applies the writes in the order they were added.
+	Write() error
+}
+
+// Options struct
+type Options struct {
+	ReadOnly    bool          // Open the store in read-only mode.
+	Sync        bool          // Flush every write to disk before returning.
+	CacheSize   int           // Capacity of the cache in MiB, 0 for the backend default.
+	LockTimeout time.Duration // How long to wait for a store locked by another process, 0 to fail at once.
+}
+
+// Opener opens the store of a backend stored in path. A nil options uses the defaults.
+type Opener func(path string, options *Options) (BFTXStore, error)
+
+var (
+	backendsMtx sync.RWMutex
+	backends    = map[string]Opener{}
+)
+
+// Register makes a backend available to Open by name, replacing a backend with the same name.
+func Register(name string, open Opener) {
+	backendsMtx.Lock()
+	defer backendsMtx.Unlock()
+	backends[name] = open
+}
+
+// Backends returns the names of the registered backends, sorted.
+func Backends() []string {
+	backendsMtx.RLock()
+	defer backendsMtx.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens the store of the backend registered with the given name. An empty name uses DefaultBackend.
+func Open(backend, path string, options *Options) (BFTXStore, error) {
+	if backend == "" {
+		backend = DefaultBackend
+	}
+	backendsMtx.RLock()
+	open, ok := backends[backend]
+	backendsMtx.RUnlock()
+	if !ok {
+		return nil, errors.New("Unknown storage backend: " + backend + ".")
+	}
+	return open(path, options)
+}
+
+func init() {
+	Register("memory", func(path string, options *Options) (BFTXStore, error) {
+		return NewMemory(), nil
+	})
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
@@ -0,0 +1,340 @@
+// File: ./blockfreight/lib/pkg/storage/storagetest/storagetest.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+// Package storagetest provides the conformance tests every storage.BFTXStore implementation must pass.
+package storagetest
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"bytes"   // Implements functions for the manipulation of byte slices.
+	"errors"  // Implements functions to manipulate errors.
+	"fmt"     // Implements formatted I/O with functions analogous to C's printf and scanf.
+	"strings" // Implements simple functions to manipulate strings.
+	"sync"    // Provides basic synchronization primitives such as mutual exclusion locks.
+	"testing" // Provides support for automated testing of Go packages.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/pkg/storage" // Defines the BFTXStore interface of the embedded stores.
+)
+
+// Opener returns a new empty store. The suite closes it.
+type Opener func(t *testing.T) storage.BFTXStore
+
+// Run runs the conformance tests against the stores returned by open.
+func Run(t *testing.T, open Opener) {
+	tests := []struct {
+		name string
+		test func(*testing.T, storage.BFTXStore)
+	}{
+		{"PutGet", testPutGet},
+		{"Delete", testDelete},
+		{"Has", testHas},
+		{"Iterate", testIterate},
+		{"IterateStop", testIterateStop},
+		{"IterateSnapshot", testIterateSnapshot},
+		{"Batch", testBatch},
+		{"Concurrent", testConcurrent},
+		{"Close", testClose},
+	}
+	for _, tt := range tests {
+		test := tt.test
+		t.Run(tt.name, func(t *testing.T) {
+			s := open(t)
+			defer s.Close()
+			test(t, s)
+		})
+	}
+}
+
+func testPutGet(t *testing.T, s storage.BFTXStore) {
+	if _, err := s.Get([]byte("missing")); err != storage.ErrNotFound {
+		t.Errorf("Get of a missing key: got %v, want ErrNotFound", err)
+	}
+	must(t, s.Put([]byte("a"), []byte("1")))
+	must(t, s.Put([]byte("a"), []byte("2")))
+	value, err := s.Get([]byte("a"))
+	must(t, err)
+	if string(value) != "2" {
+		t.Errorf("Get after overwrite: got %q, want %q", value, "2")
+	}
+
+	// The store keeps its own copy of the value
+	buf := []byte("3")
+	must(t, s.Put([]byte("b"), buf))
+	buf[0] = 'x'
+	if value, _ := s.Get([]byte("b")); string(value) != "3" {
+		t.Errorf("Get after changing the Put buffer: got %q, want %q", value, "3")
+	}
+
+	must(t, s.Put([]byte("empty"), []byte{}))
+	if value, err := s.Get([]byte("empty")); err != nil || len(value) != 0 {
+		t.Errorf("Get of an empty value: got %q, %v", value, err)
+	}
+}
+
+func testDelete(t *testing.T, s storage.BFTXStore) {
+	must(t, s.Put([]byte("a"), []byte("1")))
+	must(t, s.Delete([]byte("a")))
+	if _, err := s.Get([]byte("a")); err != storage.ErrNotFound {
+		t.Errorf("Get after Delete: got %v, want ErrNotFound", err)
+	}
+	if err := s.Delete([]byte("missing")); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func testHas(t *testing.T, s storage.BFTXStore) {
+	must(t, s.Put([]byte("a"), []byte("1")))
+	if ok, err := s.Has([]byte("a")); err != nil || !ok {
+		t.Errorf("Has of a stored key: got %v, %v", ok, err)
+	}
+	if ok, err := s.Has([]byte("b")); err != nil || ok {
+		t.Errorf("Has of a missing key: got %v, %v", ok, err)
+	}
+}
+
+func testIterate(t *testing.T, s storage.BFTXStore) {
+	for _, key := range []string{"tx/2", "idx/a", "tx/1", "tx/10", "u"} {
+		must(t, s.Put([]byte(key), []byte("v"+key)))
+	}
+
+	keys := collect(t, s, []byte("tx/"))
+	if want := "tx/1 tx/10 tx/2"; keys != want {
+		t.Errorf("Iterate with prefix: got %q, want %q", keys, want)
+	}
+	keys = collect(t, s, nil)
+	if want := "idx/a tx/1 tx/10 tx/2 u"; keys != want {
+		t.Errorf("Iterate without prefix: got %q, want %q", keys, want)
+	}
+	if keys := collect(t, s, []byte("none/")); keys != "" {
+		t.Errorf("Iterate with an unused prefix: got %q", keys)
+	}
+
+	failure := errors.New("failure")
+	if err := s.Iterate(nil, func(key, value []byte) error { return failure }); err != failure {
+		t.Errorf("Iterate with a failing callback: got %v, want the callback error", err)
+	}
+}
+
+func testIterateStop(t *testing.T, s storage.BFTXStore) {
+	for i := 0; i < 5; i++ {
+		must(t, s.Put([]byte(fmt.Sprint(i)), []byte("v")))
+	}
+	n := 0
+	err := s.Iterate(nil, func(key, value []byte) error {
+		n++
+		if n == 2 {
+			return storage.ErrStop
+		}
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Errorf("Iterate stopped by ErrStop: got %d calls, %v", n, err)
+	}
+}
+
+func testIterateSnapshot(t *testing.T, s storage.BFTXStore) {
+	must(t, s.Put([]byte("a"), []byte("1")))
+	must(t, s.Put([]byte("b"), []byte("2")))
+
+	// Writes made by the callback are not seen by the running iteration
+	var values []string
+	err := s.Iterate(nil, func(key, value []byte) error {
+		values = append(values, string(key)+"="+string(value))
+		if err := s.Put([]byte("b"), []byte("changed")); err != nil {
+			return err
+		}
+		return s.Put([]byte("c"), []byte("3"))
+	})
+	must(t, err)
+	if got := fmt.Sprint(values); got != "[a=1 b=2]" {
+		t.Errorf("Iterate while writing: got %s, want [a=1 b=2]", got)
+	}
+	if value, _ := s.Get([]byte("c")); string(value) != "3" {
+		t.Errorf("Get of a key written during Iterate: got %q", value)
+	}
+}
+
+func testBatch(t *testing.T, s storage.BFTXStore) {
+	must(t, s.Put([]byte("old"), []byte("1")))
+
+	batch := s.Batch()
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Put([]byte("b"), []byte("2"))
+	batch.Put([]byte("a"), []byte("3"))
+	batch.Delete([]byte("old"))
+	if batch.Len() != 4 {
+		t.Errorf("Len of the batch: got %d, want 4", batch.Len())
+	}
+
+	// Nothing is visible before Write
+	if ok, _ := s.Has([]byte("a")); ok {
+		t.Error("Batch write visible before Write")
+	}
+	must(t, batch.Write())
+
+	if keys := collect(t, s, nil); keys != "a b" {
+		t.Errorf("Keys after Write: got %q, want %q", keys, "a b")
+	}
+	if value, _ := s.Get([]byte("a")); string(value) != "3" {
+		t.Errorf("Last write of the batch wins: got %q, want %q", value, "3")
+	}
+	if err := s.Batch().Write(); err != nil {
+		t.Errorf("Write of an empty batch: %v", err)
+	}
+}
+
+func testConcurrent(t *testing.T, s storage.BFTXStore) {
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				key := []byte(fmt.Sprintf("%d/%d", worker, j))
+				if err := s.Put(key, key); err != nil {
+					errs <- err
+					return
+				}
+				value, err := s.Get(key)
+				if err != nil || !bytes.Equal(value, key) {
+					errs <- fmt.Errorf("Get of %s: got %q, %v", key, value, err)
+					return
+				}
+				if err := s.Iterate([]byte(fmt.Sprintf("%d/", worker)), func(key, value []byte) error { return nil }); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if n, _ := storage.Total(s); n != 200 {
+		t.Errorf("Total after concurrent writes: got %d, want 200", n)
+	}
+}
+
+func testClose(t *testing.T, s storage.BFTXStore) {
+	must(t, s.Put([]byte("a"), []byte("1")))
+	batch := s.Batch()
+	batch.Put([]byte("b"), []byte("2"))
+	must(t, s.Close())
+
+	if err := s.Put([]byte("a"), []byte("1")); err != storage.ErrClosed {
+		t.Errorf("Put after Close: got %v, want ErrClosed", err)
+	}
+	if _, err := s.Get([]byte("a")); err != storage.ErrClosed {
+		t.Errorf("Get after Close: got %v, want ErrClosed", err)
+	}
+	if err := s.Delete([]byte("a")); err != storage.ErrClosed {
+		t.Errorf("Delete after Close: got %v, want ErrClosed", err)
+	}
+	if _, err := s.Has([]byte("a")); err != storage.ErrClosed {
+		t.Errorf("Has after Close: got %v, want ErrClosed", err)
+	}
+	if err := s.Iterate(nil, func(key, value []byte) error { return nil }); err != storage.ErrClosed {
+		t.Errorf("Iterate after Close: got %v, want ErrClosed", err)
+	}
+	if err := batch.Write(); err != storage.ErrClosed {
+		t.Errorf("Batch Write after Close: got %v, want ErrClosed", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("Second Close: %v", err)
+	}
+}
+
+// collect returns the keys starting with prefix, separated by spaces.
+func collect(t *testing.T, s storage.BFTXStore, prefix []byte) string {
+	var keys []string
+	err := s.Iterate(prefix, func(key, value []byte) error {
+		keys = append(keys, string(key))
+		return nil
+	})
+	must(t, err)
+	return strings.Join(keys, " ")
+}
+
+func must(t *testing.T, err error) {
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
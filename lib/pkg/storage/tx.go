@@ -0,0 +1,193 @@
+// File: ./blockfreight/lib/pkg/storage/tx.go
+// Summary: Application code for Blockfreight™ | The blockchain of global freight.
+// License: MIT License
+// Company: Blockfreight, Inc.
+// Author: Julian Nunez, Neil Tran, Julian Smith, Gian Felipe & contributors
+// Site: https://blockfreight.com
+// Support: <support@blockfreight.com>
+
+// Copyright © 2017 Blockfreight, Inc. All Rights Reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS
+// OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY,
+// WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// =================================================================================================================================================
+// =================================================================================================================================================
+//
+// BBBBBBBBBBBb     lll                                kkk             ffff                         iii                  hhh            ttt
+// BBBB``````BBBB   lll                                kkk            fff                           ```                  hhh            ttt
+// BBBB      BBBB   lll      oooooo        ccccccc     kkk    kkkk  fffffff  rrr  rrr    eeeee      iii     gggggg ggg   hhh  hhhhh   tttttttt
+// BBBBBBBBBBBB     lll    ooo    oooo    ccc    ccc   kkk   kkk    fffffff  rrrrrrrr eee    eeee   iii   gggg   ggggg   hhhh   hhhh  tttttttt
+// BBBBBBBBBBBBBB   lll   ooo      ooo   ccc           kkkkkkk        fff    rrrr    eeeeeeeeeeeee  iii  gggg      ggg   hhh     hhh    ttt
+// BBBB       BBB   lll   ooo      ooo   ccc           kkkk kkkk      fff    rrr     eeeeeeeeeeeee  iii   ggg      ggg   hhh     hhh    ttt
+// BBBB      BBBB   lll   oooo    oooo   cccc    ccc   kkk   kkkk     fff    rrr      eee      eee  iii    ggg    gggg   hhh     hhh    tttt    ....
+// BBBBBBBBBBBBB    lll     oooooooo       ccccccc     kkk     kkkk   fff    rrr       eeeeeeeee    iii     gggggg ggg   hhh     hhh     ttttt  ....
+//                                                                                                        ggg      ggg
+//   Blockfreight™ | The blockchain of global freight.                                                      ggggggggg
+//
+// =================================================================================================================================================
+// =================================================================================================================================================
+
+package storage
+
+import (
+	// =======================
+	// Golang Standard library
+	// =======================
+	"errors" // Implements functions to manipulate errors.
+
+	// ======================
+	// Blockfreight™ packages
+	// ======================
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx" // Defines the Blockfreight™ Transaction (BF_TX) transaction standard and provides some useful functions to work with the BF_TX.
+)
+
+// maxNonce bounds the ids tried by CreateBfTx for a content.
+const maxNonce = 1000
+
+// ErrExists is returned when a new BF_TX has the id of a stored BF_TX.
+var ErrExists = errors.New("A BF_TX with this id already exists.")
+
+// Tx struct is a read-write transaction over the BF_TX of a store. Its writes are staged in a single batch, and
+// committed all together when the function given to Update returns.
+type Tx struct {
+	store  BFTXStore
+	batch  Batch
+	staged map[string][]byte // Records written by the transaction, by id.
+}
+
+// Update runs fn in a transaction and commits its writes atomically. When fn fails, nothing is written.
+// Transactions run one at a time in the process, so fn must not write to the store outside the transaction.
+func Update(s BFTXStore, fn func(tx *Tx) error) error {
+	writeMtx.Lock()
+	defer writeMtx.Unlock()
+
+	tx := &Tx{
+		store:  s,
+		batch:  s.Batch(),
+		staged: map[string][]byte{},
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if tx.batch.Len() == 0 {
+		return nil
+	}
+	return tx.batch.Write()
+}
+
+// get returns the record of a BF_TX as seen by the transaction.
+func (tx *Tx) get(id string) ([]byte, error) {
+	if json, ok := tx.staged[id]; ok {
+		return json, nil
+	}
+	if IsIndexKey([]byte(id)) {
+		return nil, ErrNotFound
+	}
+	return tx.store.Get([]byte(id))
+}
+
+// GetBfTx returns a BF_TX, including the writes of the transaction.
+func (tx *Tx) GetBfTx(id string) (bf_tx.BF_TX, error) {
+	value, err := tx.get(id)
+	if err != nil {
+		return bf_tx.BF_TX{}, err
+	}
+	return decodeRecord([]byte(id), value)
+}
+
+// PutBfTx stages the JSON content of a BF_TX and its index entries.
+func (tx *Tx) PutBfTx(id string, json string) error {
+	if IsIndexKey([]byte(id)) {
+		return errors.New("Invalid BF_TX id: " + id + ".")
+	}
+	old, err := tx.get(id)
+	if err == ErrNotFound {
+		old = nil
+	} else if err != nil {
+		return err
+	}
+	value, err := stageBfTx(tx.store, tx.batch, id, json, old)
+	if err != nil {
+		return err
+	}
+	tx.staged[id] = value
+	return nil
+}
+
+// InsertBfTx stages a new BF_TX, failing with ErrExists when its id is stored.
+func (tx *Tx) InsertBfTx(id string, json string) error {
+	if _, err := tx.get(id); err == nil {
+		return ErrExists
+	} else if err != ErrNotFound {
+		return err
+	}
+	return tx.PutBfTx(id, json)
+}
+
+// CreateBfTx gives a new BF_TX the first id generated from its content and salt which is not stored yet, and
+// stages it. The transactions run one at a time, so concurrent creations get different ids.
+func (tx *Tx) CreateBfTx(bftx *bf_tx.BF_TX, salt []byte) error {
+	for nonce := uint64(0); nonce < maxNonce; nonce++ {
+		id, err := bf_tx.GenerateBFTXID(*bftx, salt, nonce)
+		if err != nil {
+			return err
+		}
+		candidate := *bftx
+		candidate.Id = id
+		content, err := bf_tx.BFTXContent(candidate)
+		if err != nil {
+			return err
+		}
+		if err := tx.InsertBfTx(id, content); err != ErrExists {
+			if err == nil {
+				bftx.Id = id
+			}
+			return err
+		}
+	}
+	return errors.New("No free BF_TX id for this content.")
+}
+
+// =================================================
+// Blockfreight™ | The blockchain of global freight.
+// =================================================
+
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBB                    BBBBBBBBBBBBBBBBBBB
+// BBBBBBB                       BBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBB         BBBBBBBBBBBBBBBB
+// BBBBBBB                     BBBBBBBBBBBBBBBBBB
+// BBBBBBB                        BBBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBBB       BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBBB        BBBBBBBBBBBBBB
+// BBBBBBB       BBBBBBBBB        BBB       BBBBB
+// BBBBBBB                       BBBB       BBBBB
+// BBBBBBB                    BBBBBBB       BBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+// BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+
+// ==================================================
+// Blockfreight™ | The blockchain for global freight.
+// ==================================================
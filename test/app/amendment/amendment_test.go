@@ -0,0 +1,65 @@
+package amendment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/amendment"
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+)
+
+func TestChanges(t *testing.T) {
+	t.Log("Test on Changes")
+	old, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	amended := old
+	amended.Id = "amended"
+	if changes := amendment.Changes(old, amended); len(changes) != 0 {
+		t.Errorf("Error on Changes of the same Bill of Lading: %v", changes)
+	}
+	amended.Properties.Consignee.Name = "Another consignee"
+	amended.Properties.NumBol++
+	if changes := amendment.Changes(old, amended); !reflect.DeepEqual(changes, []string{"Consignee", "NumBol"}) {
+		t.Errorf("Error on Changes: %v", changes)
+	}
+}
+
+func TestApprove(t *testing.T) {
+	t.Log("Test on Approve")
+	revision := amendment.Revision{Id: "amended", Parent: "original", Proposer: "a", Required: []string{"a", "b"}, Approvals: []string{}}
+	if err := revision.Approve("c"); err != amendment.ErrNotRequired {
+		t.Errorf("Error on Approve by a party which is not required: %v", err)
+	}
+	if err := revision.Approve("a"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := revision.Approve("a"); err != amendment.ErrApproved {
+		t.Errorf("Error on a double Approve: %v", err)
+	}
+	if missing := revision.Missing(); !reflect.DeepEqual(missing, []string{"b"}) {
+		t.Errorf("Error on Missing: %v", missing)
+	}
+	revision.Approve("b")
+
+	decoded, err := amendment.DecodeRevision(revision.Encode())
+	if err != nil || !reflect.DeepEqual(decoded, revision) || len(decoded.Missing()) != 0 {
+		t.Errorf("Error on the encoding of the revision: %+v %v", decoded, err)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	t.Log("Test on Require")
+	revision := amendment.Revision{Id: "amended", Parent: "original", Proposer: "a", Required: []string{"a", "b"}, Approvals: []string{"a", "b"}}
+	revision.Require([]string{"a", "c"})
+	if !reflect.DeepEqual(revision.Approvals, []string{"a"}) {
+		t.Errorf("Error on the approvals kept by Require: %v", revision.Approvals)
+	}
+	if missing := revision.Missing(); !reflect.DeepEqual(missing, []string{"c"}) {
+		t.Errorf("Error on Missing after Require: %v", missing)
+	}
+	if err := revision.Approve("b"); err != amendment.ErrNotRequired {
+		t.Errorf("Error on Approve by a party no longer required: %v", err)
+	}
+}
@@ -1,6 +1,13 @@
 package bf_tx
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -72,7 +79,118 @@ func TestReinitialize(t *testing.T) {
 
 	newBftx := bftx.Reinitialize(prop)
 
-	if newBftx.PrivateKey.Curve != nil || newBftx.PrivateKey.X != nil || newBftx.PrivateKey.D != nil || newBftx.Signhash != nil || newBftx.Signature != "" || newBftx.Verified != false || newBftx.Transmitted != false {
+	if newBftx.PublicKey != "" || newBftx.Signhash != nil || newBftx.Signature != "" || newBftx.Verified != false || newBftx.Transmitted != false {
 		t.Error("Error on BF_TX object returned by function bf_tx.Reinitialize()")
 	}
 }
+
+// Golden vectors of the canonical content of examples/bf_tx_example.json.
+const goldenContent = `{"Properties":{"AgentForMaster":{"FirstName":"Agent First Name","LastName":"Agent Last Name","Sig":""},"AgentForOwner":{"FirstName":"Owner First Name","LastName":"Owner Last Name","Sig":""},"BolNum":"15554","Cargo":[{"Description":"This is the goods description.","GrossWeight":{"Unit":"KGM","Value":15523},"HSCode":"220421","Marks":"PI/LGB/001-020","PackageType":"PALLET","Packages":20,"Volume":{"Unit":"MTQ","Value":33.2}}],"ConditionsForCarriage":"There are the carriage conditions.","Consignee":{"Address":{"City":"Long Beach","Country":"US","Lines":["100 Harbor Blvd"],"PostalCode":"90802","Region":"CA"},"Contacts":null,"Name":"Pacific Imports LLC","TaxId":""},"DateShipped":"2016-11-28","Freight":{"AdvanceAmount":0,"Charges":[{"Amount":3545.34,"Description":"Ocean freight","Terms":"PREPAID"}],"Currency":"AUD","PayableAmount":3545.34,"Terms":"PREPAID"},"GeneralInstructions":"There are many general instructions.","IssueDetails":{"DateOfIssue":"2016-11-28","PlaceOfIssue":"Melbourne, Australia"},"MasterInfo":{"FirstName":"Master First Name","LastName":"Master Last Name","Sig":""},"NotifyParty":{"Address":{"City":"Long Beach","Country":"US","Lines":["100 Harbor Blvd"],"PostalCode":"90802","Region":"CA"},"Contacts":null,"Name":"Pacific Imports LLC","TaxId":""},"NumBol":3,"PortOfDischarge":{"Locode":"USLGB","Name":"Long Beach"},"PortOfLoading":{"Locode":"AUMEL","Name":"Melbourne"},"RefNum":"154532165","Shipper":{"Address":{"City":"Southbank","Country":"AU","Lines":["89-91 City Rd"],"PostalCode":"3006","Region":"VIC"},"Contacts":[{"Email":"shipping@example.com","Name":"Shipping Desk","Phone":"+61 3 9000 0000"}],"Name":"VLX454323F Exports Pty Ltd","TaxId":"51824753556"},"Vessel":{"IMONumber":"9321483","Name":"Southern Star","Voyage":"042E"}},"Type":"object"}`
+const goldenHash = "33cd2e47971b12de1c1c9ea9cef3fdf959ac7e8c41e88e7a1f47278a458391ea"
+
+func TestCanonicalContent(t *testing.T) {
+	t.Log("Test on CanonicalContent function")
+	newBftx, err := bftx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	content, err := bftx.CanonicalContent(newBftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(content) != goldenContent {
+		t.Error("Error on canonical content of the example BF_TX")
+		t.Error(string(content))
+	}
+
+	hash, err := bftx.HashBFTX(newBftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if fmt.Sprintf("%x", hash) != goldenHash {
+		t.Errorf("Error on HashBFTX of the example BF_TX: got %x", hash)
+	}
+
+	// Bookkeeping attributes are not part of the content
+	newBftx.Id = "id"
+	newBftx.Signature = "signature"
+	newBftx.Verified = true
+	newBftx.Transmitted = true
+	newBftx.Amendment = "amendment"
+	hash, _ = bftx.HashBFTX(newBftx)
+	if fmt.Sprintf("%x", hash) != goldenHash {
+		t.Error("Error on HashBFTX when bookkeeping attributes change")
+	}
+}
+
+func TestConvertLegacy(t *testing.T) {
+	t.Log("Test on ConvertLegacy function")
+	data, err := ioutil.ReadFile("../../../examples/bf_tx_example_legacy.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bftx.IsLegacy(data) {
+		t.Fatal("Error on IsLegacy with the legacy example")
+	}
+
+	converted, err := bftx.ConvertLegacy(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	prop := converted.Properties
+	if prop.BolNum != "15554" || prop.Shipper.Name != "VLX454323F" || prop.Vessel.IMONumber != "132153456" {
+		t.Error("Error on identifiers converted by ConvertLegacy")
+	}
+	if len(prop.Cargo) != 1 || prop.Cargo[0].GrossWeight.Value != 15523 || prop.Cargo[0].GrossWeight.Unit != "KGM" {
+		t.Error("Error on cargo converted by ConvertLegacy")
+	}
+	if prop.DateShipped != "2016-11-28" || prop.IssueDetails.DateOfIssue != "2016-11-28" {
+		t.Error("Error on dates converted by ConvertLegacy")
+	}
+	if prop.ConditionsForCarriage != "There are the carriage conditions." {
+		t.Error("Error on ConditionsForCarriage converted by ConvertLegacy")
+	}
+
+	// The converted BF_TX round-trips through the new format
+	encoded, err := json.Marshal(converted)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if bftx.IsLegacy(encoded) {
+		t.Error("Error on IsLegacy with a converted BF_TX")
+	}
+	decoded, err := bftx.DecodeBFTX(encoded)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(decoded, converted) {
+		t.Error("Error on JSON round-trip of a converted BF_TX")
+	}
+
+	// A signed legacy BF_TX keeps the public key of its signer, not the private key, and is not verified
+	data, err = ioutil.ReadFile("../../../examples/bf_tx_example_legacy_signed.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var legacy struct {
+		PrivateKey struct{ X, Y *big.Int }
+		Verified   bool
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil || !legacy.Verified {
+		t.Fatalf("Error on the signed legacy example: %v", err)
+	}
+	converted, err = bftx.ConvertLegacy(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if converted.PublicKey != hex.EncodeToString(elliptic.Marshal(elliptic.P256(), legacy.PrivateKey.X, legacy.PrivateKey.Y)) {
+		t.Errorf("Error on the public key converted by ConvertLegacy: %q", converted.PublicKey)
+	}
+	if converted.Verified || converted.Signature == "" {
+		t.Error("Error on the signature converted by ConvertLegacy")
+	}
+	if encoded, _ = json.Marshal(converted); bytes.Contains(encoded, []byte("PrivateKey")) {
+		t.Error("Error on ConvertLegacy: the private key is kept")
+	}
+}
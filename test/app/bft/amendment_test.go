@@ -0,0 +1,235 @@
+package bft
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/amendment"
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/app/envelope"
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle"
+	"github.com/blockfreight/go-bftx/lib/app/title"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+// sign delivers the signature of the BF_TX id by the party.
+func (p *party) sign(t *testing.T, app *bft.BftApplication, id string) types.Result {
+	p.nonce++
+	env := envelope.New(envelope.TxSign, p.nonce, []byte(id))
+	if err := env.Sign(p.key); err != nil {
+		t.Fatal(err.Error())
+	}
+	return app.DeliverTx(env.Encode())
+}
+
+// amend delivers an amendment request signed by the party.
+func (p *party) amend(t *testing.T, app *bft.BftApplication, req amendment.Request) types.Result {
+	p.nonce++
+	payload, _ := json.Marshal(req)
+	env := envelope.New(envelope.TxAmend, p.nonce, payload)
+	if err := env.Sign(p.key); err != nil {
+		t.Fatal(err.Error())
+	}
+	return app.DeliverTx(env.Encode())
+}
+
+// amendedBFTX returns the BF_TX with a new id and instructions, signed by a party.
+func amendedBFTX(t *testing.T, bftx bf_tx.BF_TX, id string, p *party) json.RawMessage {
+	bftx.Id = id
+	bftx.Properties.GeneralInstructions = "Amended: " + bftx.Properties.GeneralInstructions
+	bftx, err := crypto.SignBFTX(bf_tx.Reinitialize(bftx), p.key)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	content, _ := bf_tx.BFTXContent(bftx)
+	return json.RawMessage(content)
+}
+
+func TestAmend(t *testing.T) {
+	t.Log("Test on the amendments of DeliverTx")
+	app := bft.NewBftApplication()
+	bftx := negotiableBFTX(t)
+	carrier := &party{key: signer, nonce: 1}
+	if res := app.DeliverTx(createTx(t, bftx, carrier.nonce)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	shipper, bank, stranger := newParty(), newParty(), newParty()
+	if res := carrier.transition(t, app, bftx.Id, lifecycle.Issued); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, shipper.pubkey()); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := bank.sign(t, app, bftx.Id); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+
+	// The amendment is signed by the issuer, changes the bill and is proposed by a party
+	proposal := amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-1", carrier)}
+	if res := stranger.amend(t, app, proposal); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on an amendment proposed by a stranger: got %v", res.Code)
+	}
+	forged := amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-1", shipper)}
+	if res := shipper.amend(t, app, forged); res.Code != bft.ErrBftxMissingSignature.Code {
+		t.Errorf("Error on an amendment not signed by the issuer: got %v", res.Code)
+	}
+	unchanged := bftx
+	unchanged.Id = "bftx-test-1"
+	content, _ := bf_tx.BFTXContent(unchanged)
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: json.RawMessage(content)}); res.Code != bft.ErrBftxInvalidFields.Code {
+		t.Errorf("Error on an amendment without changes: got %v", res.Code)
+	}
+
+	// The holder and the signers approve the amendment of the carrier
+	if res := carrier.amend(t, app, proposal); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	pending, err := amendment.DecodeProposal(app.Query(types.RequestQuery{Path: bft.PathPending + bftx.Id}).Value)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if missing := pending.Missing(); !reflect.DeepEqual(missing, []string{shipper.pubkey(), bank.pubkey()}) {
+		t.Errorf("Error on the approvals of the pending amendment: %v", missing)
+	}
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-1"}); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on a double approval: got %v", res.Code)
+	}
+	if res := shipper.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-2"}); res.Code != bft.ErrBftxNotFound.Code {
+		t.Errorf("Error on the approval of another amendment: got %v", res.Code)
+	}
+	if res := stranger.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-1"}); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on the approval by a stranger: got %v", res.Code)
+	}
+	for _, p := range []*party{shipper, bank} {
+		if res := p.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-1"}); res.IsErr() {
+			t.Fatal(res.Error())
+		}
+	}
+	app.Commit()
+
+	// The revisions are linked and the amendment carries on the bill
+	parent, _ := amendment.DecodeRevision(app.Query(types.RequestQuery{Path: bft.PathRevision + bftx.Id}).Value)
+	if parent.Next != "bftx-test-1" || parent.Parent != "" || parent.Proposer != carrier.pubkey() {
+		t.Errorf("Error on the revision of the original: %+v", parent)
+	}
+	revision, _ := amendment.DecodeRevision(app.Query(types.RequestQuery{Path: bft.PathRevision + "bftx-test-1"}).Value)
+	if revision.Parent != bftx.Id || revision.Next != "" || !reflect.DeepEqual(revision.Changes, []string{"GeneralInstructions"}) || len(revision.Missing()) != 0 {
+		t.Errorf("Error on the revision of the amendment: %+v", revision)
+	}
+	if res := app.Query(types.RequestQuery{Path: bft.PathPending + bftx.Id}); res.Value != nil {
+		t.Errorf("Error on the pending amendment once approved: %s", res.Value)
+	}
+	if res := app.Query(types.RequestQuery{Path: bft.PathBol + bftx.Properties.BolNum}); string(res.Key) != string(bft.BftxKey("bftx-test-1")) {
+		t.Errorf("Error on the bill of lading number of the amendment: %s", res.Key)
+	}
+	record, _ := title.DecodeRecord(app.Query(types.RequestQuery{Path: bft.PathTitle + "bftx-test-1"}).Value)
+	if record.Holder != shipper.pubkey() {
+		t.Errorf("Error on the title of the amendment: %+v", record)
+	}
+
+	// The amended BF_TX is closed
+	if res := carrier.transition(t, app, bftx.Id, lifecycle.ShippedOnBoard); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on a transition of the amended BF_TX: got %v", res.Code)
+	}
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-2", carrier)}); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on a second amendment of the amended BF_TX: got %v", res.Code)
+	}
+	if res := carrier.transition(t, app, "bftx-test-1", lifecycle.ShippedOnBoard); res.IsErr() {
+		t.Error(res.Error())
+	}
+}
+
+func TestAmendReservedId(t *testing.T) {
+	t.Log("Test on the id of a pending amendment")
+	app := bft.NewBftApplication()
+	bftx := negotiableBFTX(t)
+	carrier := &party{key: signer, nonce: 1}
+	if res := app.DeliverTx(createTx(t, bftx, carrier.nonce)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	bank := newParty()
+	if res := bank.sign(t, app, bftx.Id); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-1", carrier)}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+
+	// Another BF_TX cannot be created under the id of the pending amendment
+	taken := bftx
+	taken.Id = "bftx-test-1"
+	taken.Properties.BolNum = "BOL-TAKEN"
+	taken, _ = crypto.SignBFTX(bf_tx.Reinitialize(taken), signer)
+	carrier.nonce++
+	if res := app.DeliverTx(createTx(t, taken, carrier.nonce)); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on a BF_TX created with the id of a pending amendment: got %v", res.Code)
+	}
+
+	// A new proposal frees the id of the one it replaces
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-2", carrier)}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	carrier.nonce++
+	if res := app.DeliverTx(createTx(t, taken, carrier.nonce)); res.IsErr() {
+		t.Errorf("Error on a BF_TX created with the id of a replaced amendment: %s", res.Error())
+	}
+
+	// The approval of a pending amendment whose id is taken is rejected
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-1", carrier)}); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on an amendment with the id of an existing BF_TX: got %v", res.Code)
+	}
+	if res := bank.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-2"}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := app.Query(types.RequestQuery{Path: bft.PathBol + bftx.Properties.BolNum}); string(res.Key) != string(bft.BftxKey("bftx-test-2")) {
+		t.Errorf("Error on the bill of lading number of the amendment: %s", res.Key)
+	}
+	if res := app.Query(types.RequestQuery{Path: bft.PathState, Data: bft.ReservedKey("bftx-test-2")}); res.Value != nil {
+		t.Errorf("Error on the reserved id once approved: %s", res.Value)
+	}
+}
+
+func TestAmendTransferred(t *testing.T) {
+	t.Log("Test on the approvals of an amendment once the title is transferred")
+	app := bft.NewBftApplication()
+	bftx := negotiableBFTX(t)
+	carrier := &party{key: signer, nonce: 1}
+	if res := app.DeliverTx(createTx(t, bftx, carrier.nonce)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	shipper, bank := newParty(), newParty()
+	if res := bank.sign(t, app, bftx.Id); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.amend(t, app, amendment.Request{Parent: bftx.Id, BFTX: amendedBFTX(t, bftx, "bftx-test-1", carrier)}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+
+	// The holder at the time of the approval has to approve too
+	if res := carrier.transition(t, app, bftx.Id, lifecycle.Issued); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, shipper.pubkey()); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := bank.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-1"}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	pending, err := amendment.DecodeProposal(app.Query(types.RequestQuery{Path: bft.PathPending + bftx.Id}).Value)
+	if err != nil {
+		t.Fatalf("Error on the pending amendment: %v", err)
+	}
+	if missing := pending.Missing(); !reflect.DeepEqual(missing, []string{shipper.pubkey()}) {
+		t.Errorf("Error on the approvals of the pending amendment: %v", missing)
+	}
+	if res := shipper.amend(t, app, amendment.Request{Parent: bftx.Id, Id: "bftx-test-1"}); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if revision, _ := amendment.DecodeRevision(app.Query(types.RequestQuery{Path: bft.PathRevision + bftx.Id}).Value); revision.Next != "bftx-test-1" {
+		t.Errorf("Error on the revision of the original: %+v", revision)
+	}
+}
@@ -0,0 +1,197 @@
+package bft
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/app/envelope"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+var signer, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+func signedBFTX(t *testing.T) bf_tx.BF_TX {
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Id = "bftx-test"
+	bftx, err = crypto.SignBFTX(bftx, signer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return bftx
+}
+
+func createTx(t *testing.T, bftx bf_tx.BF_TX, nonce uint64) []byte {
+	content, err := bf_tx.BFTXContent(bftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	env := envelope.New(envelope.TxCreate, nonce, []byte(content))
+	if err := env.Sign(signer); err != nil {
+		t.Fatal(err.Error())
+	}
+	return env.Encode()
+}
+
+func TestErrorCodes(t *testing.T) {
+	t.Log("Test on the ABCI codes of the classes of invalid BF_TX")
+	classes := map[string]types.Result{
+		"encoding":          bft.ErrBftxEncoding,
+		"invalid fields":    bft.ErrBftxInvalidFields,
+		"missing signature": bft.ErrBftxMissingSignature,
+		"invalid pubkey":    bft.ErrBftxInvalidPubKey,
+		"invalid signature": bft.ErrBftxInvalidSignature,
+		"duplicate":         bft.ErrBftxDuplicate,
+		"not found":         bft.ErrBftxNotFound,
+		"bad nonce":         bft.ErrBftxBadNonce,
+		"invalid state":     bft.ErrBftxInvalidState,
+		"unauthorized":      bft.ErrBftxUnauthorized,
+	}
+	codes := make(map[types.CodeType]string)
+	for class, res := range classes {
+		if res.IsOK() {
+			t.Errorf("Error on the code of %s: OK", class)
+		}
+		if other, exists := codes[res.Code]; exists {
+			t.Errorf("Error on the code of %s: %v is also the code of %s", class, res.Code, other)
+		}
+		codes[res.Code] = class
+	}
+	if bft.ErrBftxInvalidState.Code != bft.CodeBftxInvalidState || bft.ErrBftxUnauthorized.Code != bft.CodeBftxUnauthorized {
+		t.Errorf("Error on the application codes: %v %v", bft.ErrBftxInvalidState.Code, bft.ErrBftxUnauthorized.Code)
+	}
+}
+
+func TestCheckTx(t *testing.T) {
+	t.Log("Test on CheckTx function")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+
+	if res := app.CheckTx(createTx(t, bftx, 1)); res.IsErr() {
+		t.Error("Error on CheckTx with a signed BF_TX: " + res.Log)
+	}
+
+	if res := app.CheckTx([]byte("garbage")); res.Code != bft.ErrBftxEncoding.Code {
+		t.Errorf("Error on CheckTx with garbage: got %v", res.Code)
+	}
+
+	unsigned := bf_tx.Reinitialize(bftx)
+	unsigned.PublicKey = bftx.PublicKey
+	if res := app.CheckTx(createTx(t, unsigned, 1)); res.Code != bft.ErrBftxMissingSignature.Code {
+		t.Errorf("Error on CheckTx with an unsigned BF_TX: got %v", res.Code)
+	}
+
+	tampered := bftx
+	tampered.Properties.Shipper.Name = "Tampered"
+	if res := app.CheckTx(createTx(t, tampered, 1)); res.Code != bft.ErrBftxInvalidSignature.Code {
+		t.Errorf("Error on CheckTx with a tampered BF_TX: got %v", res.Code)
+	}
+
+	invalid := bftx
+	invalid.Properties.BolNum = ""
+	if res := app.CheckTx(createTx(t, invalid, 1)); res.Code != bft.ErrBftxInvalidFields.Code {
+		t.Errorf("Error on CheckTx with invalid fields: got %v", res.Code)
+	}
+}
+
+func TestDeliverTx(t *testing.T) {
+	t.Log("Test on DeliverTx function")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+
+	// A BF_TX whose JSON contains "=" must be stored untouched under its id
+	bftx.Properties.GeneralInstructions = "key=value"
+	bftx, _ = crypto.SignBFTX(bf_tx.Reinitialize(bftx), signer)
+	tx := createTx(t, bftx, 1)
+	if res := app.DeliverTx(tx); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	resQuery := app.Query(types.RequestQuery{Data: bft.BftxKey(bftx.Id)})
+	stored, err := bf_tx.DecodeBFTX(resQuery.Value)
+	if err != nil || stored.Properties.GeneralInstructions != "key=value" {
+		t.Error("Error on BF_TX stored by DeliverTx")
+	}
+
+	// Replaying the same envelope is rejected
+	if res := app.DeliverTx(tx); res.Code != bft.ErrBftxBadNonce.Code {
+		t.Errorf("Error on DeliverTx replay: got %v", res.Code)
+	}
+
+	// Creating the same BF_TX twice is rejected
+	if res := app.DeliverTx(createTx(t, bftx, 2)); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on DeliverTx duplicate: got %v", res.Code)
+	}
+
+	// Another BF_TX cannot take over its bill of lading number
+	other := bftx
+	other.Id = "bftx-other"
+	other, _ = crypto.SignBFTX(bf_tx.Reinitialize(other), signer)
+	if res := app.DeliverTx(createTx(t, other, 3)); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on DeliverTx with a bill of lading number already held: got %v", res.Code)
+	}
+	if res := app.Query(types.RequestQuery{Path: bft.PathBol + bftx.Properties.BolNum}); string(res.Key) != string(bft.BftxKey(bftx.Id)) {
+		t.Errorf("Error on Query by bill of lading number: got %s", res.Key)
+	}
+
+	// Any party can sign an existing BF_TX once
+	env := envelope.New(envelope.TxSign, 4, []byte(bftx.Id))
+	env.Sign(signer)
+	if res := app.DeliverTx(env.Encode()); res.IsErr() {
+		t.Error("Error on DeliverTx sign: " + res.Log)
+	}
+	env = envelope.New(envelope.TxSign, 5, []byte(bftx.Id))
+	env.Sign(signer)
+	if res := app.DeliverTx(env.Encode()); res.Code != bft.ErrBftxDuplicate.Code {
+		t.Errorf("Error on DeliverTx duplicate sign: got %v", res.Code)
+	}
+}
+
+func TestPersistentBftApplicationRecovery(t *testing.T) {
+	t.Log("Test on NewPersistentBftApplication function")
+	dir, err := ioutil.TempDir("", "bft-persist")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	bftx := signedBFTX(t)
+	app := bft.NewPersistentBftApplication(dir)
+	app.BeginBlock([]byte("hash"), &types.Header{Height: 1})
+	if res := app.DeliverTx(createTx(t, bftx, 1)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	res := app.Commit()
+	if res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	appHash := res.Data
+	app.Close()
+
+	// Reload the application from disk
+	app = bft.NewPersistentBftApplication(dir)
+	defer app.Close()
+
+	resInfo := app.Info()
+	if resInfo.LastBlockHeight != 1 {
+		t.Errorf("Error on LastBlockHeight after restart: got %d, expected 1", resInfo.LastBlockHeight)
+	}
+	if !bytes.Equal(resInfo.LastBlockAppHash, appHash) {
+		t.Error("Error on LastBlockAppHash after restart")
+	}
+
+	resQuery := app.Query(types.RequestQuery{Data: bft.BftxKey(bftx.Id)})
+	if resQuery.Value == nil {
+		t.Error("Error on state recovered after restart")
+	}
+}
@@ -0,0 +1,92 @@
+package bft
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/app/envelope"
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle"
+)
+
+// transitionTx returns a transition envelope of the BF_TX id to state, signed by key.
+func transitionTx(t *testing.T, key *ecdsa.PrivateKey, id string, state lifecycle.State, nonce uint64) []byte {
+	payload, _ := json.Marshal(lifecycle.Request{Id: id, State: state})
+	env := envelope.New(envelope.TxTransition, nonce, payload)
+	if err := env.Sign(key); err != nil {
+		t.Fatal(err.Error())
+	}
+	return env.Encode()
+}
+
+func TestLifecycle(t *testing.T) {
+	t.Log("Test on the lifecycle transitions of DeliverTx")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+	if res := app.DeliverTx(createTx(t, bftx, 1)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	consignee, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	stranger, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	// A transition which skips states is rejected
+	if res := app.CheckTx(transitionTx(t, signer, bftx.Id, lifecycle.InTransit, 2)); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on CheckTx of an invalid transition: got %v", res.Code)
+	}
+	if res := app.CheckTx(transitionTx(t, signer, bftx.Id, "lost", 2)); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on CheckTx of an unknown state: got %v", res.Code)
+	}
+	if res := app.CheckTx(transitionTx(t, signer, "missing", lifecycle.Issued, 2)); res.Code != bft.ErrBftxNotFound.Code {
+		t.Errorf("Error on CheckTx of a missing BF_TX: got %v", res.Code)
+	}
+
+	// Only the issuer issues the bill
+	if res := app.DeliverTx(transitionTx(t, stranger, bftx.Id, lifecycle.Issued, 1)); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on DeliverTx by another party: got %v", res.Code)
+	}
+	nonce := uint64(2)
+	for _, state := range []lifecycle.State{lifecycle.Issued, lifecycle.ShippedOnBoard, lifecycle.InTransit, lifecycle.Discharged} {
+		if res := app.DeliverTx(transitionTx(t, signer, bftx.Id, state, nonce)); res.IsErr() {
+			t.Fatalf("Error on DeliverTx to %s: %s", state, res.Log)
+		}
+		nonce++
+	}
+
+	// The bill is discharged, void is too late and only the holder surrenders it, even after signing it
+	if res := app.DeliverTx(transitionTx(t, signer, bftx.Id, lifecycle.Void, nonce)); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on DeliverTx to void after discharge: got %v", res.Code)
+	}
+	env := envelope.New(envelope.TxSign, 1, []byte(bftx.Id))
+	env.Sign(consignee)
+	if res := app.DeliverTx(env.Encode()); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := app.DeliverTx(transitionTx(t, consignee, bftx.Id, lifecycle.Surrendered, 2)); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on DeliverTx surrender by a party which does not hold the bill: got %v", res.Code)
+	}
+	if res := app.DeliverTx(transitionTx(t, signer, bftx.Id, lifecycle.Surrendered, nonce+1)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	nonce++
+	app.Commit()
+
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathLifecycle + bftx.Id})
+	record, err := lifecycle.DecodeRecord(resQuery.Value)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if record.State != lifecycle.Surrendered || len(record.History) != 6 {
+		t.Errorf("Error on Query of the lifecycle: %+v", record)
+	}
+	if entry := record.History[5]; entry.From != lifecycle.Discharged || entry.Height != 1 {
+		t.Errorf("Error on the last lifecycle entry: %+v", entry)
+	}
+	if res := app.DeliverTx(transitionTx(t, signer, bftx.Id, lifecycle.Accomplished, nonce+1)); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on DeliverTx from a final state: got %v", res.Code)
+	}
+}
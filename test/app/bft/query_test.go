@@ -0,0 +1,127 @@
+package bft
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+func TestQuery(t *testing.T) {
+	t.Log("Test on Query function")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+	if res := app.DeliverTx(createTx(t, bftx, 1)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	app.Commit()
+
+	for _, path := range []string{bft.PathBftx + bftx.Id, bft.PathBol + bftx.Properties.BolNum} {
+		resQuery := app.Query(types.RequestQuery{Path: path})
+		stored, err := bf_tx.DecodeBFTX(resQuery.Value)
+		if !resQuery.Code.IsOK() || err != nil || stored.Id != bftx.Id {
+			t.Errorf("Error on Query %s: %v", path, resQuery)
+		}
+		if resQuery.Height != 1 {
+			t.Errorf("Error on Query %s height: got %d, expected 1", path, resQuery.Height)
+		}
+	}
+
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathState, Data: bft.BftxKey(bftx.Id)})
+	if resQuery.Value == nil {
+		t.Error("Error on Query /state")
+	}
+	size := fmt.Sprintf("{\"size\":%s}", app.Query(types.RequestQuery{Path: bft.PathSize}).Value)
+	if resInfo := app.Info(); resInfo.Data != size {
+		t.Errorf("Error on Query /size: got %s, expected %s", size, resInfo.Data)
+	}
+	if resQuery = app.Query(types.RequestQuery{Path: bft.PathBftx + "missing"}); resQuery.Value != nil {
+		t.Error("Error on Query of a missing BF_TX")
+	}
+	if resQuery = app.Query(types.RequestQuery{Path: "/block"}); resQuery.Code != types.CodeType_UnknownRequest {
+		t.Errorf("Error on Query of an unknown path: got %v", resQuery.Code)
+	}
+	if resQuery = app.Query(types.RequestQuery{Path: bft.PathSize, Height: 7}); resQuery.Code.IsOK() {
+		t.Error("Error on Query of an unknown height")
+	}
+	if resQuery = app.Query(types.RequestQuery{Path: bft.PathSize, Height: 1}); !resQuery.Code.IsOK() {
+		t.Error("Error on Query of the current height: " + resQuery.Log)
+	}
+}
+
+func TestQueryProof(t *testing.T) {
+	t.Log("Test on VerifyProof function")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+	if res := app.DeliverTx(createTx(t, bftx, 1)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	appHash := app.Commit().Data
+
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathBftx + bftx.Id, Prove: true})
+	if err := bft.VerifyProof(resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != nil {
+		t.Error("Error on VerifyProof: " + err.Error())
+	}
+
+	tampered := append([]byte{}, resQuery.Value...)
+	tampered[0] = ' '
+	if err := bft.VerifyProof(resQuery.Key, tampered, resQuery.Proof, appHash); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyProof of a tampered value: %v", err)
+	}
+	if err := bft.VerifyProof(resQuery.Key, resQuery.Value, resQuery.Proof, []byte("other hash")); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyProof against another app hash: %v", err)
+	}
+	if err := bft.VerifyProof(resQuery.Key, resQuery.Value, []byte{0xff, 0x01}, appHash); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyProof of a garbage proof: %v", err)
+	}
+
+	resQuery = app.Query(types.RequestQuery{Path: bft.PathBftx + "missing", Prove: true})
+	if err := bft.VerifyProof(resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != bft.ErrProofMissing {
+		t.Errorf("Error on VerifyProof of a missing BF_TX: %v", err)
+	}
+}
+
+func TestQueryBolProof(t *testing.T) {
+	t.Log("Test on VerifyBolProof function")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+	other := bftx
+	other.Id = "bftx-other"
+	other.Properties.BolNum = "BOL-OTHER"
+	other, _ = crypto.SignBFTX(bf_tx.Reinitialize(other), signer)
+	for nonce, b := range []bf_tx.BF_TX{bftx, other} {
+		if res := app.DeliverTx(createTx(t, b, uint64(nonce+1))); res.IsErr() {
+			t.Fatal(res.Error())
+		}
+	}
+	appHash := app.Commit().Data
+
+	num := bftx.Properties.BolNum
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathBol + num, Prove: true})
+	if err := bft.VerifyBolProof(num, resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != nil {
+		t.Error("Error on VerifyBolProof: " + err.Error())
+	}
+	if err := bft.VerifyProof(resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyProof of a proof by bill of lading number: %v", err)
+	}
+
+	// Another BF_TX proven in the same state does not hold the bill of lading number
+	resOther := app.Query(types.RequestQuery{Path: bft.PathBftx + other.Id, Prove: true})
+	var proof bft.BolProof
+	json.Unmarshal(resQuery.Proof, &proof)
+	forged := bft.BolProof{Bol: proof.Bol, Bftx: resOther.Proof}.Encode()
+	if err := bft.VerifyBolProof(num, resOther.Key, resOther.Value, forged, appHash); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyBolProof of another BF_TX: %v", err)
+	}
+	if err := bft.VerifyBolProof("BOL-OTHER", resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != bft.ErrProofInvalid {
+		t.Errorf("Error on VerifyBolProof of another bill of lading number: %v", err)
+	}
+	if err := bft.VerifyBolProof(num, resQuery.Key, resQuery.Value, nil, appHash); err != bft.ErrProofMissing {
+		t.Errorf("Error on VerifyBolProof without proof: %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package bft
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/app/envelope"
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle"
+	"github.com/blockfreight/go-bftx/lib/app/title"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+// party is a holder of a bill, with the nonce of its next envelope.
+type party struct {
+	key   *ecdsa.PrivateKey
+	nonce uint64
+}
+
+func newParty() *party {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	return &party{key: key}
+}
+
+func (p *party) pubkey() string {
+	return hex.EncodeToString(elliptic.Marshal(elliptic.P256(), p.key.X, p.key.Y))
+}
+
+// transfer delivers a transfer of the BF_TX id signed by the party.
+func (p *party) transfer(t *testing.T, app *bft.BftApplication, id string, kind title.Kind, to string) types.Result {
+	p.nonce++
+	payload, _ := json.Marshal(title.Request{Id: id, Kind: kind, To: to})
+	env := envelope.New(envelope.TxTransfer, p.nonce, payload)
+	if err := env.Sign(p.key); err != nil {
+		t.Fatal(err.Error())
+	}
+	return app.DeliverTx(env.Encode())
+}
+
+// transition delivers a lifecycle transition of the BF_TX id signed by the party.
+func (p *party) transition(t *testing.T, app *bft.BftApplication, id string, state lifecycle.State) types.Result {
+	p.nonce++
+	return app.DeliverTx(transitionTx(t, p.key, id, state, p.nonce))
+}
+
+// negotiableBFTX returns a signed BF_TX made out to order.
+func negotiableBFTX(t *testing.T) bf_tx.BF_TX {
+	bftx := signedBFTX(t)
+	bftx.Properties.Consignee = bf_tx.Party{}
+	bftx, err := crypto.SignBFTX(bf_tx.Reinitialize(bftx), signer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return bftx
+}
+
+func TestTransfer(t *testing.T) {
+	t.Log("Test on the transfers of title of DeliverTx")
+	app := bft.NewBftApplication()
+	bftx := negotiableBFTX(t)
+	carrier := &party{key: signer, nonce: 1}
+	if res := app.DeliverTx(createTx(t, bftx, carrier.nonce)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	shipper, bank, stranger := newParty(), newParty(), newParty()
+
+	// A draft is not endorsed
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, shipper.pubkey()); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on the endorsement of a draft: got %v", res.Code)
+	}
+	if res := carrier.transition(t, app, bftx.Id, lifecycle.Issued); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+
+	// Only the holder endorses the bill, once
+	if res := stranger.transfer(t, app, bftx.Id, title.KindEndorse, stranger.pubkey()); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on the endorsement by a party which does not hold the bill: got %v", res.Code)
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, "not a key"); res.Code != bft.ErrBftxInvalidPubKey.Code {
+		t.Errorf("Error on the endorsement to an invalid key: got %v", res.Code)
+	}
+	if res := carrier.transfer(t, app, bftx.Id, "steal", shipper.pubkey()); res.Code != bft.ErrBftxInvalidFields.Code {
+		t.Errorf("Error on a transfer of an unknown kind: got %v", res.Code)
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, shipper.pubkey()); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, stranger.pubkey()); res.Code != bft.ErrBftxUnauthorized.Code {
+		t.Errorf("Error on a double transfer: got %v", res.Code)
+	}
+	if res := shipper.transfer(t, app, bftx.Id, title.KindBlank, bank.pubkey()); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+
+	// The bank surrenders the bill once it is discharged
+	if res := bank.transfer(t, app, bftx.Id, title.KindSurrender, ""); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on the surrender of a bill not discharged: got %v", res.Code)
+	}
+	for _, state := range []lifecycle.State{lifecycle.ShippedOnBoard, lifecycle.InTransit, lifecycle.Discharged} {
+		if res := carrier.transition(t, app, bftx.Id, state); res.IsErr() {
+			t.Fatal(res.Error())
+		}
+	}
+	if res := bank.transfer(t, app, bftx.Id, title.KindSurrender, ""); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := bank.transfer(t, app, bftx.Id, title.KindEndorse, shipper.pubkey()); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on a transfer after the surrender: got %v", res.Code)
+	}
+	app.Commit()
+
+	record, err := title.DecodeRecord(app.Query(types.RequestQuery{Path: bft.PathTitle + bftx.Id}).Value)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if record.Holder != carrier.pubkey() || !record.Surrendered || len(record.Endorsements) != 3 {
+		t.Errorf("Error on Query of the title: %+v", record)
+	}
+	if chain := record.Endorsements; chain[0].To != shipper.pubkey() || chain[1].From != shipper.pubkey() || chain[2].From != bank.pubkey() {
+		t.Errorf("Error on the endorsement chain: %+v", chain)
+	}
+	history, _ := lifecycle.DecodeRecord(app.Query(types.RequestQuery{Path: bft.PathLifecycle + bftx.Id}).Value)
+	if history.State != lifecycle.Surrendered || history.History[len(history.History)-1].By != bank.pubkey() {
+		t.Errorf("Error on the lifecycle after the surrender: %+v", history)
+	}
+}
+
+func TestTransferStraightBill(t *testing.T) {
+	t.Log("Test on the transfers of title of a straight bill")
+	app := bft.NewBftApplication()
+	bftx := signedBFTX(t)
+	carrier := &party{key: signer, nonce: 1}
+	if res := app.DeliverTx(createTx(t, bftx, carrier.nonce)); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.transition(t, app, bftx.Id, lifecycle.Issued); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	if res := carrier.transfer(t, app, bftx.Id, title.KindEndorse, newParty().pubkey()); res.Code != bft.ErrBftxInvalidState.Code {
+		t.Errorf("Error on the endorsement of a straight bill: got %v", res.Code)
+	}
+}
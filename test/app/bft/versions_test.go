@@ -0,0 +1,136 @@
+package bft
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/tendermint/abci/types"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/app/bft"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+// historyApp is the interface shared by the in-memory and the persistent applications.
+type historyApp interface {
+	DeliverTx(tx []byte) types.Result
+	Commit() types.Result
+	Query(reqQuery types.RequestQuery) types.ResponseQuery
+	SetOption(key string, value string) string
+}
+
+// commitBlock delivers a BF_TX with the bill of lading number BOL-{height} and commits, and returns the app hash.
+func commitBlock(t *testing.T, app historyApp, height int) []byte {
+	bftx := signedBFTX(t)
+	bftx.Id = fmt.Sprintf("bftx-%d", height)
+	bftx.Properties.BolNum = fmt.Sprintf("BOL-%d", height)
+	bftx, err := crypto.SignBFTX(bf_tx.Reinitialize(bftx), signer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if res := app.DeliverTx(createTx(t, bftx, uint64(height))); res.IsErr() {
+		t.Fatal(res.Error())
+	}
+	return app.Commit().Data
+}
+
+// assertVersion checks the BF_TX delivered at a height is in the state of that height, with a valid proof, and the
+// BF_TX of the next height is not.
+func assertVersion(t *testing.T, app historyApp, height int, appHash []byte) {
+	next := app.Query(types.RequestQuery{Path: bft.PathBol + fmt.Sprintf("BOL-%d", height+1), Height: uint64(height)})
+	if len(next.Value) != 0 {
+		t.Errorf("Error on Query at height %d: the BF_TX of the next height exists", height)
+	}
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathBol + fmt.Sprintf("BOL-%d", height), Height: uint64(height), Prove: true})
+	if !resQuery.Code.IsOK() {
+		t.Fatalf("Error on Query at height %d: %s", height, resQuery.Log)
+	}
+	bftx, err := bf_tx.DecodeBFTX(resQuery.Value)
+	if err != nil || bftx.Id != fmt.Sprintf("bftx-%d", height) || resQuery.Height != uint64(height) {
+		t.Errorf("Error on Query at height %d: got %s", height, bftx.Id)
+	}
+	if err := bft.VerifyBolProof(fmt.Sprintf("BOL-%d", height), resQuery.Key, resQuery.Value, resQuery.Proof, appHash); err != nil {
+		t.Errorf("Error on VerifyBolProof at height %d: %s", height, err.Error())
+	}
+}
+
+func assertPruned(t *testing.T, app historyApp, height int) {
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathSize, Height: uint64(height)})
+	if resQuery.Code != types.CodeType_BaseInvalidInput {
+		t.Errorf("Error on Query at the pruned height %d: got %v", height, resQuery.Code)
+	}
+}
+
+func TestHistoricalQuery(t *testing.T) {
+	t.Log("Test on Query function at past heights")
+	app := bft.NewBftApplication()
+	if log := app.SetOption(bft.OptionRetainHeights, "3"); log != "" {
+		t.Fatal(log)
+	}
+	if log := app.SetOption(bft.OptionRetainHeights, "many"); log == "" {
+		t.Error("Error on SetOption with an invalid retention")
+	}
+
+	hashes := map[int][]byte{}
+	for height := 1; height <= 3; height++ {
+		hashes[height] = commitBlock(t, app, height)
+	}
+	for height := 1; height <= 3; height++ {
+		assertVersion(t, app, height, hashes[height])
+	}
+	first, _ := strconv.Atoi(string(app.Query(types.RequestQuery{Path: bft.PathSize, Height: 1}).Value))
+	last, _ := strconv.Atoi(string(app.Query(types.RequestQuery{Path: bft.PathSize, Height: 3}).Value))
+	if first == 0 || last <= first {
+		t.Errorf("Error on Query /size at heights 1 and 3: got %d and %d", first, last)
+	}
+	resQuery := app.Query(types.RequestQuery{Path: bft.PathSize, Height: 4})
+	if resQuery.Code.IsOK() {
+		t.Error("Error on Query at a height not committed yet")
+	}
+
+	// The fourth block prunes the first height
+	hashes[4] = commitBlock(t, app, 4)
+	assertPruned(t, app, 1)
+	for height := 2; height <= 4; height++ {
+		assertVersion(t, app, height, hashes[height])
+	}
+}
+
+func TestPersistentHistoricalQuery(t *testing.T) {
+	t.Log("Test on Query function at past heights of NewPersistentBftApplication")
+	dir, err := ioutil.TempDir("", "bft-history")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	app := bft.NewPersistentBftApplication(dir)
+	app.SetOption(bft.OptionRetainHeights, "5")
+	hashes := map[int][]byte{}
+	for height := 1; height <= 12; height++ {
+		hashes[height] = commitBlock(t, app, height)
+	}
+	for height := 8; height <= 12; height++ {
+		assertVersion(t, app, height, hashes[height])
+	}
+	assertPruned(t, app, 7)
+	app.Close()
+
+	// The heights kept survive a restart, their nodes were not deleted
+	app = bft.NewPersistentBftApplication(dir)
+	defer app.Close()
+	app.SetOption(bft.OptionRetainHeights, "5")
+	for height := 8; height <= 12; height++ {
+		assertVersion(t, app, height, hashes[height])
+	}
+	assertPruned(t, app, 7)
+
+	hashes[13] = commitBlock(t, app, 13)
+	assertPruned(t, app, 8)
+	for height := 9; height <= 13; height++ {
+		assertVersion(t, app, height, hashes[height])
+	}
+}
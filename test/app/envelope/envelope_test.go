@@ -0,0 +1,54 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/envelope"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Log("Test on Encode and Decode functions")
+	privatekey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Payloads are binary-safe, "=" and zero bytes included
+	env := envelope.New(envelope.TxCreate, 42, []byte("key=value\x00{}"))
+	if err := env.Sign(privatekey); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	decoded, err := envelope.Decode(env.Encode())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if decoded.Type != envelope.TxCreate || decoded.Nonce != 42 || !bytes.Equal(decoded.Payload, env.Payload) {
+		t.Error("Error on envelope decoded")
+	}
+	if err := decoded.Verify(); err != nil {
+		t.Error("Error on Verify of a decoded envelope: " + err.Error())
+	}
+
+	decoded.Payload = []byte("tampered")
+	if err := decoded.Verify(); err != envelope.ErrInvalidSignature {
+		t.Error("Error on Verify of a tampered envelope")
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	t.Log("Test on Decode function with malformed input")
+	if _, err := envelope.Decode([]byte{}); err != envelope.ErrMalformed {
+		t.Error("Error on Decode of an empty envelope")
+	}
+	if _, err := envelope.Decode([]byte{99, 1}); err != envelope.ErrUnsupportedVersion {
+		t.Error("Error on Decode of an unknown version")
+	}
+	if _, err := envelope.Decode([]byte{envelope.Version, 1, 200}); err != envelope.ErrMalformed {
+		t.Error("Error on Decode of a truncated envelope")
+	}
+}
@@ -0,0 +1,77 @@
+package lifecycle
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/lifecycle"
+)
+
+func TestTransitions(t *testing.T) {
+	t.Log("Test on Find function")
+	path := []lifecycle.State{lifecycle.Draft, lifecycle.Issued, lifecycle.ShippedOnBoard, lifecycle.InTransit, lifecycle.Discharged, lifecycle.Accomplished}
+	for i := 1; i < len(path); i++ {
+		if _, err := lifecycle.Find(path[i-1], path[i]); err != nil {
+			t.Errorf("Error on Find from %s to %s: %s", path[i-1], path[i], err.Error())
+		}
+	}
+
+	invalid := [][2]lifecycle.State{
+		{lifecycle.Draft, lifecycle.ShippedOnBoard},
+		{lifecycle.Issued, lifecycle.Draft},
+		{lifecycle.InTransit, lifecycle.Void},
+		{lifecycle.Accomplished, lifecycle.Surrendered},
+		{lifecycle.Void, lifecycle.Issued},
+	}
+	for _, states := range invalid {
+		if _, err := lifecycle.Find(states[0], states[1]); err != lifecycle.ErrInvalidTransition {
+			t.Errorf("Error on Find from %s to %s: %v", states[0], states[1], err)
+		}
+	}
+
+	if transition, _ := lifecycle.Find(lifecycle.Discharged, lifecycle.Surrendered); transition.Role != lifecycle.RoleHolder {
+		t.Error("Error on Find: the holder surrenders the bill")
+	}
+	if transition, _ := lifecycle.Find(lifecycle.Draft, lifecycle.Issued); transition.Role != lifecycle.RoleIssuer {
+		t.Error("Error on Find: only the issuer issues the bill")
+	}
+
+	for _, state := range []lifecycle.State{lifecycle.Surrendered, lifecycle.Accomplished, lifecycle.Void} {
+		if next := lifecycle.Next(state); len(next) != 0 {
+			t.Errorf("Error on Next of the final state %s: %v", state, next)
+		}
+	}
+	if next := lifecycle.Next(lifecycle.Discharged); !reflect.DeepEqual(next, []lifecycle.State{lifecycle.Surrendered, lifecycle.Accomplished}) {
+		t.Errorf("Error on Next of discharged: %v", next)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Log("Test on Parse function")
+	for _, state := range lifecycle.States() {
+		if parsed, err := lifecycle.Parse(string(state)); err != nil || parsed != state {
+			t.Errorf("Error on Parse %s", state)
+		}
+	}
+	if _, err := lifecycle.Parse("lost"); err != lifecycle.ErrUnknownState {
+		t.Errorf("Error on Parse of an unknown state: %v", err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	t.Log("Test on Record type")
+	record := lifecycle.NewRecord("issuer", 1)
+	transition, _ := lifecycle.Find(lifecycle.Draft, lifecycle.Issued)
+	record.Apply(transition, "issuer", 2)
+
+	decoded, err := lifecycle.DecodeRecord(record.Encode())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(decoded, record) || decoded.State != lifecycle.Issued || len(decoded.History) != 2 {
+		t.Errorf("Error on DecodeRecord: %+v", decoded)
+	}
+	if entry := decoded.History[1]; entry.From != lifecycle.Draft || entry.To != lifecycle.Issued || entry.Height != 2 {
+		t.Errorf("Error on Apply: %+v", entry)
+	}
+}
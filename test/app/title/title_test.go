@@ -0,0 +1,51 @@
+package title
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/title"
+)
+
+func TestRecord(t *testing.T) {
+	t.Log("Test on Record type")
+	record := title.NewRecord("carrier")
+
+	if err := record.Check(title.Request{Id: "bftx", Kind: "steal", To: "thief"}); err != title.ErrUnknownKind {
+		t.Errorf("Error on Check of an unknown kind: %v", err)
+	}
+	if err := record.Check(title.Request{Id: "bftx", Kind: title.KindEndorse}); err != title.ErrNoEndorsee {
+		t.Errorf("Error on Check of an endorsement without endorsee: %v", err)
+	}
+
+	for _, req := range []title.Request{
+		{Id: "bftx", Kind: title.KindEndorse, To: "shipper"},
+		{Id: "bftx", Kind: title.KindBlank, To: "bank"},
+	} {
+		if err := record.Check(req); err != nil {
+			t.Fatal(err.Error())
+		}
+		record.Apply(req, "carrier", 2)
+	}
+	if record.Holder != "bank" || !record.Bearer {
+		t.Errorf("Error on Apply of a blank endorsement: %+v", record)
+	}
+
+	record.Apply(title.Request{Id: "bftx", Kind: title.KindSurrender}, "carrier", 3)
+	if record.Holder != "carrier" || record.Bearer || !record.Surrendered {
+		t.Errorf("Error on Apply of a surrender: %+v", record)
+	}
+	if err := record.Check(title.Request{Id: "bftx", Kind: title.KindEndorse, To: "bank"}); err != title.ErrSurrendered {
+		t.Errorf("Error on Check after the surrender: %v", err)
+	}
+
+	expected := []title.Endorsement{
+		{Kind: title.KindEndorse, From: "carrier", To: "shipper", Height: 2},
+		{Kind: title.KindBlank, From: "shipper", To: "bank", Height: 2},
+		{Kind: title.KindSurrender, From: "bank", To: "carrier", Height: 3},
+	}
+	decoded, err := title.DecodeRecord(record.Encode())
+	if err != nil || !reflect.DeepEqual(decoded.Endorsements, expected) {
+		t.Errorf("Error on the endorsement chain: %+v", decoded.Endorsements)
+	}
+}
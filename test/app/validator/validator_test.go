@@ -1,10 +1,15 @@
 package validator
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
 	"github.com/blockfreight/go-bftx/lib/app/validator"
+	"github.com/blockfreight/go-bftx/lib/pkg/i18n"
 )
 
 func TestValidator(t *testing.T) {
@@ -13,13 +18,199 @@ func TestValidator(t *testing.T) {
 	if err != nil {
 		t.Log(err.Error())
 	}
-	result, err := validator.ValidateBFTX(bftx)
+	result := validator.ValidateBFTX(bftx)
+	if !result.Valid || len(result.Issues) != 0 {
+		t.Error("Error on result of TestValidator")
+		t.Error(result.Issues)
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	t.Log("Test on ValidateJSON function")
+	schema := "../../../examples/bf_tx_schema_pub_var_rfc2.json"
+	for _, example := range []string{"bf_tx_example.json", "bf_tx_example_legacy.json"} {
+		data, err := ioutil.ReadFile("../../../examples/" + example)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		result, err := validator.ValidateJSON(schema, data)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		violations := result.Issues
+		// The legacy vessel number is not an IMO number
+		if example == "bf_tx_example_legacy.json" {
+			if len(violations) != 1 || violations[0].Path != "/Properties/Vessel/IMONumber" {
+				t.Errorf("Error on ValidateJSON with %s: %v", example, violations)
+			}
+		} else if len(violations) != 0 {
+			t.Errorf("Error on ValidateJSON with %s: %v", example, violations)
+		}
+	}
+
+	// Every violation is reported with its JSON pointer
+	data := []byte(`{
+		"Type": "object",
+		"Properties": {
+			"BolNum": "",
+			"Shipper": {"Name": "Shipper"},
+			"Vessel": {"Name": "Vessel", "IMONumber": "12"},
+			"PortOfLoading": {"Locode": "aumel"},
+			"PortOfDischarge": {},
+			"Cargo": [{"Description": "Goods", "GrossWeight": {"Value": 10, "Unit": "KG"}}],
+			"NumBol": 0,
+			"Unknown": true
+		}
+	}`)
+	result, err := validator.ValidateJSON(schema, data)
 	if err != nil {
-		t.Log(err.Error())
+		t.Fatal(err.Error())
 	}
+	if result.Valid || result.Schema != "https://blockfreight.com/schemas/bf_tx/2.0.0" {
+		t.Error("Error on ValidateJSON result of an invalid BF_TX")
+	}
+	violations := result.Issues
+	expected := []string{
+		"/Properties/BolNum",
+		"/Properties/Cargo/0/GrossWeight/Unit",
+		"/Properties/NumBol",
+		"/Properties/PortOfDischarge",
+		"/Properties/PortOfLoading/Locode",
+		"/Properties/Unknown",
+		"/Properties/Vessel/IMONumber",
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Error on number of violations: %v", violations)
+	}
+	for i, violation := range violations {
+		if violation.Path != expected[i] || violation.Severity != validator.SeverityError {
+			t.Errorf("Error on violation %d: got %s, expected %s", i, violation.Path, expected[i])
+		}
+	}
+}
 
-	if result != "Success! [OK]" {
-		t.Error("Error on result of TestValidator")
-		t.Error(result)
+func TestValidateBFTX(t *testing.T) {
+	t.Log("Test on ValidateBFTX function")
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Properties.BolNum = ""
+	bftx.Properties.Consignee.Name = ""
+	bftx.Properties.PortOfLoading.Locode = "AU-MEL"
+	bftx.Properties.Cargo[0].HSCode = ""
+	bftx.Properties.Cargo[0].GrossWeight.Unit = "KG"
+	bftx.Properties.NumBol = 0
+
+	result := validator.ValidateBFTX(bftx)
+	if result.Valid {
+		t.Fatal("Error on ValidateBFTX with an invalid BF_TX")
+	}
+	expected := []validator.Issue{
+		{Path: "/Properties/BolNum", Code: validator.CodeRequired, Severity: validator.SeverityError},
+		{Path: "/Properties/Consignee/Name", Code: validator.CodeRequired, Severity: validator.SeverityWarning},
+		{Path: "/Properties/PortOfLoading/Locode", Code: validator.CodeFormat, Severity: validator.SeverityError},
+		{Path: "/Properties/Cargo/0/HSCode", Code: validator.CodeRequired, Severity: validator.SeverityWarning},
+		{Path: "/Properties/Cargo/0/GrossWeight/Unit", Code: validator.CodeInvalidValue, Severity: validator.SeverityError},
+		{Path: "/Properties/NumBol", Code: validator.CodeOutOfRange, Severity: validator.SeverityError},
+	}
+	if len(result.Issues) != len(expected) {
+		t.Fatalf("Error on number of issues: %v", result.Issues)
+	}
+	for i, issue := range result.Issues {
+		if issue.Path != expected[i].Path || issue.Code != expected[i].Code || issue.Severity != expected[i].Severity || issue.Rule == "" || issue.Message == "" {
+			t.Errorf("Error on issue %d: %s", i, issue.String())
+		}
+	}
+	if len(result.Errors()) != 4 || len(result.Warnings()) != 2 || result.Err() == nil {
+		t.Error("Error on Errors, Warnings or Err of the ValidationResult")
+	}
+
+	// Warnings alone keep the BF_TX valid
+	bftx.Properties.BolNum = "15554"
+	bftx.Properties.PortOfLoading.Locode = "AUMEL"
+	bftx.Properties.Cargo[0].GrossWeight.Unit = "KGM"
+	bftx.Properties.NumBol = 3
+	result = validator.ValidateBFTX(bftx)
+	if !result.Valid || result.Err() != nil || len(result.Warnings()) != 2 {
+		t.Errorf("Error on ValidateBFTX with warnings only: %v", result.Issues)
+	}
+}
+
+func TestValidationResultJSON(t *testing.T) {
+	t.Log("Test on the JSON encoding of ValidationResult")
+	result := validator.NewValidationResult()
+	result.Add(validator.Issue{Path: "/Properties/BolNum", Rule: "required", Code: validator.CodeRequired, Severity: validator.SeverityError, Message: "Value must not be empty."})
+	out, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := `{"valid":false,"issues":[{"path":"/Properties/BolNum","rule":"required","code":"REQUIRED","severity":"error","message":"Value must not be empty."}]}`
+	if string(out) != expected {
+		t.Errorf("Error on JSON encoding of ValidationResult: %s", out)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	t.Log("Test on ValidationResult.Localize function")
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Properties.BolNum = ""
+	result := validator.ValidateBFTX(bftx)
+	if result.Issues[0].Message != "Value must not be empty." {
+		t.Errorf("Error on default message: %s", result.Issues[0].Message)
+	}
+
+	localized := result.Localize(i18n.New("es"))
+	if localized.Issues[0].Message != "El valor no puede estar vacío." || result.Issues[0].Message != "Value must not be empty." {
+		t.Errorf("Error on localized message: %s", localized.Issues[0].Message)
+	}
+	if localized.Err().Error() != "BF_TX inválido: error REQUIRED /Properties/BolNum: El valor no puede estar vacío." {
+		t.Errorf("Error on localized error: %s", localized.Err().Error())
+	}
+
+	// Schema violations are localized with their parameters
+	data := []byte(`{"Type": "object", "Properties": {"BolNum": "1", "NumBol": 0}}`)
+	schemaResult, err := validator.ValidateJSON("../../../examples/bf_tx_schema_pub_var_rfc2.json", data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, issue := range schemaResult.Localize(i18n.New("ja")).Issues {
+		if issue.Path == "/Properties/NumBol" && issue.Message != "値は 1 以上でなければなりません。" {
+			t.Errorf("Error on localized schema message: %s", issue.Message)
+		}
+	}
+}
+
+func TestSchemaPath(t *testing.T) {
+	t.Log("Test on SchemaPath function")
+	if path := validator.SchemaPath("/etc/bftx/schema.json"); path != "/etc/bftx/schema.json" {
+		t.Errorf("Error on SchemaPath of a configured schema: %s", path)
+	}
+	if path := validator.SchemaPath(""); path != validator.DefaultSchema {
+		t.Errorf("Error on SchemaPath without a schema next to the executable: %s", path)
+	}
+
+	// The schema next to the executable is used from any working directory
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	path := filepath.Join(filepath.Dir(executable), validator.DefaultSchema)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(filepath.Dir(path))
+	schema, _ := ioutil.ReadFile("../../../examples/bf_tx_schema_pub_var_rfc2.json")
+	if err := ioutil.WriteFile(path, schema, 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+	if found := validator.SchemaPath(""); found != path {
+		t.Errorf("Error on SchemaPath with a schema next to the executable: %s", found)
+	}
+	if _, err := validator.ValidateJSON(validator.SchemaPath(""), []byte(`{}`)); err != nil {
+		t.Errorf("Error on ValidateJSON with the schema next to the executable: %v", err)
 	}
 }
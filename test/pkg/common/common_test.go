@@ -19,3 +19,29 @@ func TestHashByteArrays(t *testing.T) {
 		t.Error("Error on HashByteArrays!")
 	}
 }
+
+func TestCanonicalJSON(t *testing.T) {
+	t.Log("Test CanonicalJSON on Common Lib")
+	vectors := []struct {
+		value    interface{}
+		expected string
+	}{
+		{map[string]interface{}{"b": 2, "a": 1}, `{"a":1,"b":2}`},
+		{map[string]interface{}{"\u20ac": 1, "\r": 2, "\U0001F600": 3, "\u00f6": 4}, "{\"\\r\":2,\"\u00f6\":4,\"\u20ac\":1,\"\U0001F600\":3}"},
+		{[]interface{}{1e21, 1e-7, -0.000001, 1.5, 0.0, 54684010805}, `[1e+21,1e-7,-0.000001,1.5,0,54684010805]`},
+		{"<tag>&\u0001\"\\", `"<tag>&\u0001\"\\"`},
+		{struct{ B, A bool }{true, false}, `{"A":false,"B":true}`},
+		{nil, `null`},
+	}
+
+	for _, vector := range vectors {
+		result, err := common.CanonicalJSON(vector.value)
+		if err != nil {
+			t.Error(err.Error())
+			continue
+		}
+		if string(result) != vector.expected {
+			t.Errorf("Error on CanonicalJSON: got %s, expected %s", result, vector.expected)
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
 	"testing"
 
 	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
@@ -14,7 +21,11 @@ func TestSignBFTX(t *testing.T) {
 		t.Log(err.Error())
 	}
 
-	bftx, err = crypto.SignBFTX(bftx)
+	privatekey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx, err = crypto.SignBFTX(bftx, privatekey)
 	if err != nil {
 		t.Log(err.Error())
 	}
@@ -25,4 +36,145 @@ func TestSignBFTX(t *testing.T) {
 	if bftx.Verified == false {
 		t.Error("Error on bf_tx.Verified")
 	}
+	if bftx.PublicKey != crypto.EncodePublicKey(&privatekey.PublicKey) {
+		t.Error("Error on bf_tx.PublicKey")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	t.Log("Test on VerifySignature function")
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Log(err.Error())
+	}
+
+	if err := crypto.VerifySignature(bftx); err != crypto.ErrMissingSignature {
+		t.Error("Error on VerifySignature with an unsigned BF_TX")
+	}
+
+	privatekey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx, err = crypto.SignBFTX(bftx, privatekey)
+	if err != nil {
+		t.Log(err.Error())
+	}
+	if err := crypto.VerifySignature(bftx); err != nil {
+		t.Error("Error on VerifySignature with a signed BF_TX: " + err.Error())
+	}
+
+	bftx.Properties.GeneralInstructions = "Tampered"
+	if err := crypto.VerifySignature(bftx); err != crypto.ErrInvalidSignature {
+		t.Error("Error on VerifySignature with a tampered BF_TX")
+	}
+}
+
+func TestVerifyBFTX(t *testing.T) {
+	t.Log("Test on VerifyBFTX function")
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Log(err.Error())
+	}
+	signer, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	bftx, err = crypto.SignBFTX(bftx, signer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The signature round-trips through its text format
+	r, s, err := crypto.DecodeSignature(bftx.Signature)
+	if err != nil || crypto.EncodeSignature(r, s) != bftx.Signature {
+		t.Error("Error on DecodeSignature")
+	}
+	if _, _, err := crypto.DecodeSignature("1234567890"); err != crypto.ErrInvalidSignature {
+		t.Error("Error on DecodeSignature with a legacy signature")
+	}
+
+	if err := crypto.VerifyBFTX(bftx, &signer.PublicKey); err != nil {
+		t.Error("Error on VerifyBFTX with the signer key: " + err.Error())
+	}
+	if err := crypto.VerifyBFTX(bftx, &other.PublicKey); err != crypto.ErrInvalidSignature {
+		t.Error("Error on VerifyBFTX with another key")
+	}
+}
+
+func TestSignatureAlgorithms(t *testing.T) {
+	t.Log("Test on signature algorithms")
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Log(err.Error())
+	}
+	privatekey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	for _, algorithm := range []string{crypto.AlgorithmSHA256, crypto.AlgorithmSHA3} {
+		signed, err := crypto.SignBFTXWithAlgorithm(bftx, privatekey, algorithm)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if signed.SignAlgorithm != algorithm || len(signed.Signhash) != 32 {
+			t.Error("Error on signature of " + algorithm)
+		}
+		if err := crypto.VerifySignature(signed); err != nil || crypto.WeakSignature(signed) {
+			t.Error("Error on VerifySignature of " + algorithm)
+		}
+	}
+
+	if _, err := crypto.SignBFTXWithAlgorithm(bftx, privatekey, crypto.AlgorithmMD5); err != crypto.ErrUnknownAlgorithm {
+		t.Error("Error on SignBFTXWithAlgorithm with MD5")
+	}
+	if _, err := crypto.SignBFTXWithAlgorithm(bftx, privatekey, "ECDSA-P256-CRC32"); err != crypto.ErrUnknownAlgorithm {
+		t.Error("Error on SignBFTXWithAlgorithm with an unknown algorithm")
+	}
+}
+
+func TestLegacyMD5Signature(t *testing.T) {
+	t.Log("Test on legacy MD5 signatures")
+
+	// Record written by the SignBFTX of the first release: MD5 of the whole BF_TX, stored private key and decimal signature
+	data, err := ioutil.ReadFile("../../../examples/bf_tx_example_legacy_signed.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var stored struct {
+		PrivateKey struct{ X, Y *big.Int }
+	}
+	json.Unmarshal(data, &stored)
+	expected := crypto.EncodePublicKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: stored.PrivateKey.X, Y: stored.PrivateKey.Y})
+
+	pubkey, err := crypto.VerifyLegacy(data)
+	if err != crypto.ErrUnverifiableSignature {
+		t.Errorf("Error on VerifyLegacy: %v", err)
+	}
+	if pubkey != expected {
+		t.Errorf("Error on the public key of VerifyLegacy: %s", pubkey)
+	}
+
+	// The hash covers the content, not the attributes changed after the signature
+	transmitted := bytes.Replace(data, []byte(`"Transmitted":false`), []byte(`"Transmitted":true`), 1)
+	if _, err := crypto.VerifyLegacy(transmitted); err != crypto.ErrUnverifiableSignature {
+		t.Errorf("Error on VerifyLegacy of a transmitted BF_TX: %v", err)
+	}
+	tampered := bytes.Replace(data, []byte("VLX454323F"), []byte("VLX454323G"), 1)
+	if _, err := crypto.VerifyLegacy(tampered); err != crypto.ErrInvalidSignature {
+		t.Errorf("Error on VerifyLegacy of a tampered BF_TX: %v", err)
+	}
+	unsigned := bytes.Replace(data, []byte(`"Signature":"`), []byte(`"Signature":"","Old":"`), 1)
+	if _, err := crypto.VerifyLegacy(unsigned); err != crypto.ErrMissingSignature {
+		t.Errorf("Error on VerifyLegacy of an unsigned BF_TX: %v", err)
+	}
+
+	// Once converted, the legacy signature is weak and cannot be verified on the BF_TX
+	bftx, err := bf_tx.ConvertLegacy(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if crypto.SignatureAlgorithm(bftx) != crypto.AlgorithmMD5 || !crypto.WeakSignature(bftx) {
+		t.Error("Error on legacy signature not flagged as weak")
+	}
+	if err := crypto.VerifySignature(bftx); err != crypto.ErrUnverifiableSignature {
+		t.Errorf("Error on VerifySignature of a converted legacy BF_TX: %v", err)
+	}
 }
@@ -0,0 +1,138 @@
+package crypto
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+)
+
+func newKeystore(t *testing.T) (*crypto.Keystore, string) {
+	dir, err := ioutil.TempDir("", "bft-keys")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ks, err := crypto.NewKeystore(dir)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ks.SetScryptN(1 << 10)
+	return ks, dir
+}
+
+func TestKeystore(t *testing.T) {
+	t.Log("Test on Keystore functions")
+	ks, dir := newKeystore(t)
+	defer os.RemoveAll(dir)
+
+	pubkey, err := ks.Create("carrier", "secret")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := ks.Create("carrier", "secret"); err != crypto.ErrKeyExists {
+		t.Error("Error on Create with a duplicated name")
+	}
+	if _, err := ks.Create("../carrier", "secret"); err != crypto.ErrInvalidKeyName {
+		t.Error("Error on Create with an invalid name")
+	}
+
+	// The private key is never stored in clear
+	content, _ := ioutil.ReadFile(dir + "/carrier.key")
+	privatekey, err := ks.Load("carrier", "secret")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if strings.Contains(string(content), privatekey.D.Text(16)) {
+		t.Error("Error on key file: private key stored in clear")
+	}
+	if crypto.EncodePublicKey(&privatekey.PublicKey) != crypto.EncodePublicKey(pubkey) {
+		t.Error("Error on Load: public key does not match")
+	}
+
+	if _, err := ks.Load("carrier", "wrong"); err != crypto.ErrWrongPassphrase {
+		t.Error("Error on Load with a wrong passphrase")
+	}
+
+	keys, err := ks.List()
+	if err != nil || len(keys) != 1 || keys[0].Name != "carrier" {
+		t.Error("Error on List")
+	}
+	if name, err := ks.Find(crypto.EncodePublicKey(pubkey)); err != nil || name != "carrier" {
+		t.Error("Error on Find")
+	}
+
+	if err := ks.Delete("carrier"); err != nil {
+		t.Error(err.Error())
+	}
+	if err := ks.Delete("carrier"); err != crypto.ErrKeyNotFound {
+		t.Error("Error on Delete of a missing key")
+	}
+}
+
+func TestKeystoreImport(t *testing.T) {
+	t.Log("Test on Keystore ImportHex function")
+	ks, dir := newKeystore(t)
+	defer os.RemoveAll(dir)
+
+	hexkey := "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"
+	pubkey, err := ks.ImportHex("shipper", hexkey, "secret")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Public key of the RFC 6979 A.2.5 P-256 test key
+	expected := "0460fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb67903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299"
+	if crypto.EncodePublicKey(pubkey) != expected {
+		t.Error("Error on ImportHex public key")
+	}
+
+	if _, err := ks.ImportHex("bad", "1234", "secret"); err == nil {
+		t.Error("Error on ImportHex with a short key")
+	}
+}
+
+func TestKeystoreConcurrentCreate(t *testing.T) {
+	t.Log("Test on concurrent Keystore Create functions with the same name")
+	ks, dir := newKeystore(t)
+	defer os.RemoveAll(dir)
+
+	// Only one key is created, the others are refused instead of overwriting it
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	results := make([]string, 32)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			pubkey, err := ks.Create("carrier", "secret")
+			if err == nil {
+				results[i] = crypto.EncodePublicKey(pubkey)
+			}
+			errs[i] = err
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	created := ""
+	for i, err := range errs {
+		switch {
+		case err == nil && created == "":
+			created = results[i]
+		case err != crypto.ErrKeyExists:
+			t.Errorf("Error on concurrent Create: %v", err)
+		}
+	}
+	privatekey, err := ks.Load("carrier", "secret")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if crypto.EncodePublicKey(&privatekey.PublicKey) != created {
+		t.Error("Error on concurrent Create: the created key was overwritten")
+	}
+}
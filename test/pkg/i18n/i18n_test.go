@@ -0,0 +1,133 @@
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/pkg/i18n"
+)
+
+func TestResolve(t *testing.T) {
+	t.Log("Test on Resolve and Normalize functions")
+	cases := map[string]string{
+		"es":          "es",
+		"es_AR.UTF-8": "es",
+		"ES":          "es",
+		"zh-Hans":     "zh",
+		"ja_JP":       "ja",
+		"fr_FR.UTF-8": "en",
+		"C":           "en",
+		"":            "en",
+	}
+	for locale, expected := range cases {
+		if resolved := i18n.Resolve(locale); resolved != expected {
+			t.Errorf("Error on Resolve(%q): got %s, expected %s", locale, resolved, expected)
+		}
+	}
+	if i18n.Resolve("fr", "it", "es") != "it" {
+		t.Error("Error on Resolve with several candidates")
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	t.Log("Test on Catalog.T function")
+	es := i18n.New("es_ES.UTF-8")
+	if es.Locale() != "es" {
+		t.Fatalf("Error on Locale: %s", es.Locale())
+	}
+	if es.T("cli.msg.bftx-id", "abc") != "Id del BF_TX: abc" {
+		t.Errorf("Error on Spanish message: %s", es.T("cli.msg.bftx-id", "abc"))
+	}
+	// Keys missing in a locale fall back to English
+	if i18n.New("ja").T("cli.cmd.sign") != i18n.Default().T("cli.cmd.sign") {
+		t.Error("Error on fallback to English")
+	}
+	// Unknown keys are printed as is
+	if es.T("unknown.key") != "unknown.key" {
+		t.Error("Error on unknown key")
+	}
+	if es.Error("cli.err.not-signed").Error() != "El BF_TX aún no está firmado." {
+		t.Error("Error on Catalog.Error")
+	}
+}
+
+var verbs = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*[0-9.]*[a-zA-Z%]`)
+
+func TestTranslations(t *testing.T) {
+	t.Log("Test on the consistency of the translations")
+	english := map[string]bool{}
+	for _, key := range i18n.Keys("en") {
+		english[key] = true
+	}
+	en := i18n.Default()
+	for _, locale := range i18n.Supported() {
+		catalog := i18n.New(locale)
+		for _, key := range i18n.Keys(locale) {
+			if !english[key] {
+				t.Errorf("Error on %s catalog: key %s is not defined in English", locale, key)
+				continue
+			}
+			// A translation takes the same arguments as the English message
+			got, expected := len(verbs.FindAllString(catalog.T(key), -1)), len(verbs.FindAllString(en.T(key), -1))
+			if got != expected {
+				t.Errorf("Error on %s catalog: key %s has %d arguments, expected %d", locale, key, got, expected)
+			}
+		}
+	}
+	// The validation messages are translated in every locale
+	for _, locale := range i18n.Supported() {
+		keys := map[string]bool{}
+		for _, key := range i18n.Keys(locale) {
+			keys[key] = true
+		}
+		for key := range english {
+			if (regexp.MustCompile(`^(validator|schema)\.`).MatchString(key)) && !keys[key] {
+				t.Errorf("Error on %s catalog: validation key %s is missing", locale, key)
+			}
+		}
+	}
+	// Only the command and flag usages fall back to English
+	usage := regexp.MustCompile(`^cli\.(cmd|flag)\.`)
+	for _, locale := range i18n.Supported() {
+		keys := map[string]bool{}
+		for _, key := range i18n.Keys(locale) {
+			keys[key] = true
+		}
+		for key := range english {
+			if !usage.MatchString(key) && !keys[key] {
+				t.Errorf("Error on %s catalog: key %s is missing", locale, key)
+			}
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Log("Test on LoadFile function")
+	dir, err := ioutil.TempDir("", "i18n")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "pt.json")
+	if err := ioutil.WriteFile(path, []byte(`{"cli.msg.signed": "BF_TX assinado"}`), 0600); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := i18n.LoadFile("pt_BR", path); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer i18n.Unregister("pt")
+	pt := i18n.New("pt")
+	if pt.Locale() != "pt" || pt.T("cli.msg.signed") != "BF_TX assinado" || pt.T("cli.msg.keys", 2) != "2 key(s)" {
+		t.Error("Error on catalog loaded from a file")
+	}
+
+	// Only the added locales are unregistered
+	i18n.Unregister("pt")
+	i18n.Unregister("es")
+	if i18n.Resolve("pt") != i18n.DefaultLocale || i18n.Resolve("es") != "es" {
+		t.Errorf("Error on Unregister: %v", i18n.Supported())
+	}
+}
@@ -0,0 +1,120 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/pkg/jsonschema"
+)
+
+const testSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://example.com/test/1",
+  "type": "object",
+  "required": ["name", "items"],
+  "properties": {
+    "name": { "type": "string", "minLength": 1 },
+    "code": { "type": "string", "pattern": "^[A-Z]{3}$" },
+    "count": { "type": "integer", "minimum": 1 },
+    "items": { "type": "array", "minItems": 1, "items": { "$ref": "#/definitions/item" } },
+    "a/b": { "enum": ["x", "y"] }
+  },
+  "additionalProperties": false,
+  "definitions": {
+    "item": {
+      "type": "object",
+      "properties": { "weight": { "type": "number", "exclusiveMinimum": 0 } },
+      "anyOf": [{ "required": ["weight"] }, { "required": ["volume"] }]
+    }
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	t.Log("Test on Schema.Validate function")
+	schema, err := jsonschema.Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if schema.ID() != "https://example.com/test/1" {
+		t.Error("Error on Schema.ID()")
+	}
+
+	violations, err := schema.Validate([]byte(`{"name": "ok", "items": [{"weight": 1.5}, {"volume": 2}]}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(violations) != 0 {
+		t.Errorf("Error on valid document: %v", violations)
+	}
+
+	violations, err = schema.Validate([]byte(`{
+		"name": "", "code": "abc", "count": 1.5, "extra": true, "a/b": "z",
+		"items": [{"weight": 0}, {}]
+	}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []jsonschema.Violation{
+		{Pointer: "/a~1b", Keyword: "enum"},
+		{Pointer: "/code", Keyword: "pattern"},
+		{Pointer: "/count", Keyword: "type"},
+		{Pointer: "/extra", Keyword: "additionalProperties"},
+		{Pointer: "/items/0/weight", Keyword: "exclusiveMinimum"},
+		{Pointer: "/items/1", Keyword: "anyOf"},
+		{Pointer: "/name", Keyword: "minLength"},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Error on number of violations: got %v", violations)
+	}
+	for i, violation := range violations {
+		if violation.Pointer != expected[i].Pointer || violation.Keyword != expected[i].Keyword {
+			t.Errorf("Error on violation %d: got %s (%s), expected %s (%s)", i, violation.Pointer, violation.Keyword, expected[i].Pointer, expected[i].Keyword)
+		}
+	}
+
+	violations, _ = schema.Validate([]byte(`{"items": []}`))
+	if len(violations) != 2 || violations[0].Pointer != "/items" || violations[1].Pointer != "/name" || violations[1].Keyword != "required" {
+		t.Errorf("Error on required and minItems violations: %v", violations)
+	}
+
+	if _, err := schema.Validate([]byte(`{"name": `)); err == nil {
+		t.Error("Error on Validate with malformed JSON")
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Log("Test on Parse function")
+	invalid := []string{
+		`[]`,
+		`{"$schema": "http://json-schema.org/draft-04/schema#"}`,
+		`{"properties": {"a": {"$ref": "#/definitions/missing"}}}`,
+		`{"properties": {"a": {"$ref": "other.json#/a"}}}`,
+		`{"properties": {"a": {"pattern": "("}}}`,
+		`{"items": 3}`,
+		`{"definitions": {"a": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`,
+		`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"allOf": [{"$ref": "#/definitions/a"}]}}}`,
+		`{"anyOf": [{"not": {"$ref": "#"}}]}`,
+	}
+	for _, schema := range invalid {
+		if _, err := jsonschema.Parse([]byte(schema)); err == nil || !strings.HasPrefix(err.Error(), jsonschema.ErrInvalidSchema.Error()) {
+			t.Errorf("Error on Parse with invalid schema %s: %v", schema, err)
+		}
+	}
+
+	// A recursive schema which goes down the document is valid
+	valid := []string{
+		`true`,
+		`{"properties": {"child": {"$ref": "#"}}}`,
+		`{"definitions": {"a": {"type": "string"}}, "allOf": [{"$ref": "#/definitions/a"}, {"$ref": "#/definitions/a"}]}`,
+	}
+	for _, schema := range valid {
+		parsed, err := jsonschema.Parse([]byte(schema))
+		if err != nil {
+			t.Errorf("Error on Parse with valid schema %s: %v", schema, err)
+			continue
+		}
+		if _, err := parsed.Validate([]byte(`{"child": {"child": {}}}`)); err != nil {
+			t.Error(err.Error())
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package leveldb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blockfreight/go-bftx/lib/pkg/leveldb"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+func openTestStore(t *testing.T, options *storage.Options) (*leveldb.Store, string) {
+	dir, err := ioutil.TempDir("", "bft-db")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	store, err := leveldb.Open(filepath.Join(dir, "db"), options)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return store, dir
+}
+
+func TestStore(t *testing.T) {
+	t.Log("Test on Store functions")
+	store, dir := openTestStore(t, &storage.Options{Sync: true})
+	defer os.RemoveAll(dir)
+	defer store.Close()
+
+	if err := store.Put([]byte("bftx-1"), []byte(`{"Id":"bftx-1"}`)); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The data survives the reopening
+	reopened, err := leveldb.Open(store.Path(), nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer reopened.Close()
+	if value, err := reopened.Get([]byte("bftx-1")); err != nil || string(value) != `{"Id":"bftx-1"}` {
+		t.Errorf("Error on Get after reopening: %s %v", value, err)
+	}
+
+	// A read-only store refuses the writes
+	reopened.Close()
+	readOnly, err := leveldb.Open(store.Path(), &storage.Options{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer readOnly.Close()
+	if err := readOnly.Put([]byte("bftx-2"), []byte("{}")); err == nil {
+		t.Error("Error on Put in a read-only store")
+	}
+}
+
+func TestConcurrentStore(t *testing.T) {
+	t.Log("Test on concurrent use of a Store")
+	store, dir := openTestStore(t, nil)
+	defer os.RemoveAll(dir)
+	defer store.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				id := fmt.Sprintf("bftx-%d-%d", worker, j)
+				if err := store.Put([]byte(id), []byte(id)); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := store.Get([]byte(id)); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := storage.Total(store); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err.Error())
+	}
+	if total, _ := storage.Total(store); total != 100 {
+		t.Errorf("Error on Total after concurrent writes: %d", total)
+	}
+}
+
+func TestLockedStore(t *testing.T) {
+	t.Log("Test on opening a Store locked by another handle")
+	store, dir := openTestStore(t, nil)
+	defer os.RemoveAll(dir)
+
+	start := time.Now()
+	if _, err := leveldb.Open(store.Path(), &storage.Options{LockTimeout: 100 * time.Millisecond}); err != leveldb.ErrLocked {
+		t.Errorf("Error on Open of a locked DB: %v", err)
+	}
+	if time.Since(start) < 100*time.Millisecond {
+		t.Error("Error on Open of a locked DB: the lock timeout was not awaited")
+	}
+
+	// The lock is acquired when released within the timeout
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		store.Close()
+	}()
+	second, err := leveldb.Open(store.Path(), &storage.Options{LockTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	second.Close()
+}
@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+// entries returns every key and value of a store.
+func entries(t *testing.T, s storage.BFTXStore) map[string]string {
+	all := map[string]string{}
+	if err := s.Iterate(nil, func(key, value []byte) error {
+		all[string(key)] = string(value)
+		return nil
+	}); err != nil {
+		t.Fatal(err.Error())
+	}
+	return all
+}
+
+func export(t *testing.T, s storage.BFTXStore) []byte {
+	var buf bytes.Buffer
+	trailer, err := storage.Export(s, &buf)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if trailer.Records != 5 || trailer.Entries != len(entries(t, s)) {
+		t.Errorf("Error on the trailer: %+v", trailer)
+	}
+	return buf.Bytes()
+}
+
+func TestExportImport(t *testing.T) {
+	t.Log("Test on Export and Import functions")
+	s := searchStore(t)
+	defer s.Close()
+	archive := export(t, s)
+
+	restored := storage.NewMemory()
+	defer restored.Close()
+	result, err := storage.Import(restored, bytes.NewReader(archive), storage.ConflictFail)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.Imported != 5 {
+		t.Errorf("Error on the import result: %+v", result)
+	}
+	if !reflect.DeepEqual(entries(t, restored), entries(t, s)) {
+		t.Error("Error on Import: the restored store differs")
+	}
+	if found, _ := storage.Search(restored, storage.Query{Conditions: conditions(t, "shipper=acme*")}); found.Total != 3 {
+		t.Errorf("Error on Search in the restored store: %v", found.Ids)
+	}
+
+	// Importing the same archive again changes nothing
+	result, err = storage.Import(restored, bytes.NewReader(archive), storage.ConflictFail)
+	if err != nil || result.Unchanged != 5 || result.Imported != 0 {
+		t.Errorf("Error on a second Import: %+v %v", result, err)
+	}
+}
+
+func TestImportConflicts(t *testing.T) {
+	t.Log("Test on the conflict policies of Import")
+	archive := export(t, searchStore(t))
+
+	// The DB has a changed bftx-1 and a BF_TX which is not in the archive
+	changed := func() storage.BFTXStore {
+		s := searchStore(t)
+		bftx, _ := storage.GetBfTx(s, "bftx-1")
+		bftx.Properties.BolNum = "BOL-1B"
+		content, _ := bf_tx.BFTXContent(bftx)
+		storage.PutBfTx(s, "bftx-1", content)
+		bftx.Id = "bftx-9"
+		content, _ = bf_tx.BFTXContent(bftx)
+		storage.PutBfTx(s, "bftx-9", content)
+		s.Delete([]byte("bftx-5"))
+		return s
+	}
+
+	s := changed()
+	before := entries(t, s)
+	result, err := storage.Import(s, bytes.NewReader(archive), storage.ConflictFail)
+	if err != storage.ErrConflict || !reflect.DeepEqual(result.Conflicts, []string{"bftx-1"}) {
+		t.Errorf("Error on Import with fail: %+v %v", result, err)
+	}
+	if !reflect.DeepEqual(entries(t, s), before) {
+		t.Error("Error on Import with fail: the store changed")
+	}
+
+	s = changed()
+	result, err = storage.Import(s, bytes.NewReader(archive), storage.ConflictSkip)
+	if err != nil || result.Imported != 1 || result.Skipped != 1 || result.Unchanged != 3 {
+		t.Errorf("Error on Import with skip: %+v %v", result, err)
+	}
+	if bftx, _ := storage.GetBfTx(s, "bftx-1"); bftx.Properties.BolNum != "BOL-1B" {
+		t.Errorf("Error on Import with skip: bftx-1 has BoL %s", bftx.Properties.BolNum)
+	}
+
+	s = changed()
+	result, err = storage.Import(s, bytes.NewReader(archive), storage.ConflictOverwrite)
+	if err != nil || result.Imported != 2 || result.Skipped != 0 {
+		t.Errorf("Error on Import with overwrite: %+v %v", result, err)
+	}
+	if bftx, _ := storage.GetBfTx(s, "bftx-1"); bftx.Properties.BolNum != "BOL-1" {
+		t.Errorf("Error on Import with overwrite: bftx-1 has BoL %s", bftx.Properties.BolNum)
+	}
+	if found, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol=BOL-1B")}); !reflect.DeepEqual(found.Ids, []string{"bftx-9"}) {
+		t.Errorf("Error on Import with overwrite: the replaced content is indexed %v", found.Ids)
+	}
+	if total, _ := storage.Total(s); total != 6 {
+		t.Errorf("Error on Import with overwrite: total %d", total)
+	}
+
+	if _, err := storage.Import(s, bytes.NewReader(archive), "merge"); err == nil {
+		t.Error("Error on Import with an unknown policy")
+	}
+}
+
+func TestImportCorrupted(t *testing.T) {
+	t.Log("Test on Import of corrupted archives")
+	archive := string(export(t, searchStore(t)))
+	lines := strings.SplitAfter(archive, "\n")
+
+	line := []byte(lines[1])
+	line[3] ^= 1
+	tampered := lines[0] + string(line) + strings.Join(lines[2:], "")
+	truncated := strings.Join(lines[:len(lines)-2], "")
+	dropped := strings.Join(append(lines[:1:1], lines[2:]...), "")
+	tests := map[string]string{"tampered": tampered, "truncated": truncated, "dropped": dropped}
+	for name, data := range tests {
+		s := storage.NewMemory()
+		if _, err := storage.Import(s, strings.NewReader(data), storage.ConflictFail); err != storage.ErrChecksum {
+			t.Errorf("Error on Import of a %s archive: %v", name, err)
+		}
+		if total, _ := storage.Total(s); total != 0 {
+			t.Errorf("Error on Import of a %s archive: %d BF_TX imported", name, total)
+		}
+	}
+
+	if _, err := storage.Import(storage.NewMemory(), strings.NewReader("{\"Id\":\"1\"}\n"), storage.ConflictFail); err == nil {
+		t.Error("Error on Import of a file which is not an archive")
+	}
+	future := strings.Replace(archive, `"version":1`, `"version":9`, 1)
+	if _, err := storage.Import(storage.NewMemory(), strings.NewReader(future), storage.ConflictFail); err == nil {
+		t.Error("Error on Import of an unsupported version")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Log("Test on Copy function")
+	s := searchStore(t)
+	defer s.Close()
+
+	dst := storage.NewMemory()
+	if n, err := storage.Copy(s, dst); err != nil || n != 5 {
+		t.Fatalf("Error on Copy: %d %v", n, err)
+	}
+	if !reflect.DeepEqual(entries(t, dst), entries(t, s)) {
+		t.Error("Error on Copy: the copy differs")
+	}
+	if _, err := storage.Copy(s, dst); err == nil {
+		t.Error("Error on Copy to a store which is not empty")
+	}
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+const goroutines = 50
+
+var salt = []byte("last block app hash")
+
+func example(t *testing.T) bf_tx.BF_TX {
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return bftx
+}
+
+func TestConstructBfTx(t *testing.T) {
+	t.Log("Test on ConstructBfTx function")
+	s := storage.NewMemory()
+	defer s.Close()
+	bftx := example(t)
+
+	// The same content, constructed from many goroutines at once, gets a different id each time
+	ids := make(chan string, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := storage.ConstructBfTx(s, bftx, salt)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			ids <- id
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[string]bool{}
+	for id := range ids {
+		if id == "" || seen[id] {
+			t.Errorf("Error on ConstructBfTx: duplicated id %q", id)
+		}
+		seen[id] = true
+	}
+	if total, _ := storage.Total(s); total != goroutines {
+		t.Errorf("Error on Total: %d", total)
+	}
+
+	// The first id is the one derived from the content and the salt only
+	first, _ := bf_tx.GenerateBFTXID(bftx, salt, 0)
+	stored, err := storage.GetBfTx(s, first)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if stored.Id != first {
+		t.Errorf("Error on ConstructBfTx: stored id %s", stored.Id)
+	}
+}
+
+func TestAppendBfTx(t *testing.T) {
+	t.Log("Test on AppendBfTx function")
+	s := searchStore(t)
+	defer s.Close()
+
+	// Many goroutines amend the same BF_TX, only one of them wins
+	ids := make(chan string, goroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := storage.AppendBfTx(s, "bftx-1", example(t), salt)
+			if err == nil {
+				ids <- id
+			} else if err != storage.ErrAmended {
+				t.Error(err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	if len(ids) != 1 {
+		t.Fatalf("Error on AppendBfTx: %d amendments", len(ids))
+	}
+	id := <-ids
+	old, _ := storage.GetBfTx(s, "bftx-1")
+	if old.Amendment != id {
+		t.Errorf("Error on AppendBfTx: link %q, expected %q", old.Amendment, id)
+	}
+	if total, _ := storage.Total(s); total != 6 {
+		t.Errorf("Error on Total: %d", total)
+	}
+
+	// Each BF_TX of a chain is amended from a different goroutine, the amendments get different ids
+	chain := []string{"bftx-2", "bftx-3", "bftx-4", "bftx-5"}
+	amendments := make([]string, len(chain))
+	for i, oldId := range chain {
+		wg.Add(1)
+		go func(i int, oldId string) {
+			defer wg.Done()
+			id, err := storage.AppendBfTx(s, oldId, example(t), salt)
+			if err != nil {
+				t.Error(err.Error())
+			}
+			amendments[i] = id
+		}(i, oldId)
+	}
+	wg.Wait()
+
+	seen := map[string]bool{id: true}
+	for i, id := range amendments {
+		if seen[id] {
+			t.Errorf("Error on AppendBfTx: duplicated id %q", id)
+		}
+		seen[id] = true
+		if old, _ := storage.GetBfTx(s, chain[i]); old.Amendment != id {
+			t.Errorf("Error on AppendBfTx: link %q, expected %q", old.Amendment, id)
+		}
+	}
+
+	if _, err := storage.AppendBfTx(s, "bftx-9", example(t), salt); err != storage.ErrNotFound {
+		t.Errorf("Error on AppendBfTx to a missing BF_TX: %v", err)
+	}
+}
+
+func TestInsertBfTx(t *testing.T) {
+	t.Log("Test on InsertBfTx function")
+	s := searchStore(t)
+	defer s.Close()
+
+	err := storage.Update(s, func(tx *storage.Tx) error {
+		if err := tx.InsertBfTx("bftx-6", "{}"); err != nil {
+			return err
+		}
+		return tx.InsertBfTx("bftx-6", "{}")
+	})
+	if err != storage.ErrExists {
+		t.Errorf("Error on InsertBfTx of a staged id: %v", err)
+	}
+	err = storage.Update(s, func(tx *storage.Tx) error {
+		return tx.InsertBfTx("bftx-1", "{}")
+	})
+	if err != storage.ErrExists {
+		t.Errorf("Error on InsertBfTx of a stored id: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+// list returns the ids listed with options, and the next cursor.
+func list(t *testing.T, s storage.BFTXStore, options storage.ListOptions) ([]string, string) {
+	ids := []string{}
+	next, err := storage.List(s, options, func(summary storage.Summary) error {
+		ids = append(ids, summary.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return ids, next
+}
+
+func TestList(t *testing.T) {
+	t.Log("Test on List function")
+	s := searchStore(t)
+	defer s.Close()
+
+	var summaries []storage.Summary
+	next, err := storage.List(s, storage.ListOptions{}, func(summary storage.Summary) error {
+		summaries = append(summaries, summary)
+		return nil
+	})
+	if err != nil || next != "" || len(summaries) != 5 {
+		t.Fatalf("Error on List: %d summaries, next %q, %v", len(summaries), next, err)
+	}
+	want := storage.Summary{Id: "bftx-2", BolNum: "BOL-2", State: "signed", Shipper: "Acme Imports", DateShipped: "2017-03-02"}
+	if summaries[1] != want {
+		t.Errorf("Error on the summary: got %+v, want %+v", summaries[1], want)
+	}
+
+	tests := []struct {
+		options storage.ListOptions
+		ids     []string
+	}{
+		{storage.ListOptions{State: "signed"}, []string{"bftx-2", "bftx-4"}},
+		{storage.ListOptions{State: "Constructed", Offset: 1}, []string{"bftx-3", "bftx-5"}},
+		{storage.ListOptions{Offset: 3}, []string{"bftx-4", "bftx-5"}},
+		{storage.ListOptions{Offset: 9}, []string{}},
+		{storage.ListOptions{Sort: storage.SortBol, Limit: 2}, []string{"bftx-1", "bftx-2"}},
+	}
+	for _, test := range tests {
+		if ids, _ := list(t, s, test.options); !reflect.DeepEqual(ids, test.ids) {
+			t.Errorf("Error on List %+v: got %v, want %v", test.options, ids, test.ids)
+		}
+	}
+}
+
+func TestListCursor(t *testing.T) {
+	t.Log("Test on the cursor pagination of List")
+	s := searchStore(t)
+	defer s.Close()
+
+	for _, order := range []string{storage.SortId, storage.SortDate} {
+		var all []string
+		options := storage.ListOptions{Sort: order, Limit: 2}
+		for pages := 0; ; pages++ {
+			if pages == 5 {
+				t.Fatalf("Error on the cursor pagination by %s: the pages do not end", order)
+			}
+			ids, next := list(t, s, options)
+			all = append(all, ids...)
+			if next == "" {
+				break
+			}
+			options.Cursor = next
+		}
+		if want := []string{"bftx-1", "bftx-2", "bftx-3", "bftx-4", "bftx-5"}; !reflect.DeepEqual(all, want) {
+			t.Errorf("Error on the cursor pagination by %s: got %v", order, all)
+		}
+	}
+
+	// A page which ends on the last BF_TX has no next page
+	if _, next := list(t, s, storage.ListOptions{Offset: 3, Limit: 2}); next != "" {
+		t.Errorf("Error on the last page: next %q", next)
+	}
+
+	_, next := list(t, s, storage.ListOptions{Limit: 2})
+	if _, err := storage.List(s, storage.ListOptions{Sort: storage.SortDate, Cursor: next}, func(storage.Summary) error { return nil }); err != storage.ErrInvalidCursor {
+		t.Errorf("Error on List with the cursor of another order: %v", err)
+	}
+	if _, err := storage.List(s, storage.ListOptions{Cursor: "not a cursor"}, func(storage.Summary) error { return nil }); err != storage.ErrInvalidCursor {
+		t.Errorf("Error on List with an invalid cursor: %v", err)
+	}
+	if _, err := storage.List(s, storage.ListOptions{Sort: "weight"}, func(storage.Summary) error { return nil }); err == nil {
+		t.Error("Error on List with an unknown order")
+	}
+}
+
+func TestListStream(t *testing.T) {
+	t.Log("Test on List stopped by the callback")
+	s := searchStore(t)
+	defer s.Close()
+
+	failure := errors.New("failure")
+	n := 0
+	_, err := storage.List(s, storage.ListOptions{}, func(storage.Summary) error {
+		n++
+		if n == 2 {
+			return failure
+		}
+		return nil
+	})
+	if err != failure || n != 2 {
+		t.Errorf("Error on List with a failing callback: %d calls, %v", n, err)
+	}
+}
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/crypto"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+// oldStore returns a store written before the record versions: a legacy BF_TX (version 0), a typed BF_TX
+// (version 1) and a current one.
+func oldStore(t *testing.T) storage.BFTXStore {
+	s := storage.NewMemory()
+	legacy, err := ioutil.ReadFile("../../../examples/bf_tx_example_legacy.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Id = "bftx-2"
+	bftx.Properties.BolNum = "BOL-2"
+	typed, _ := bf_tx.BFTXContent(bftx)
+	s.Put([]byte("bftx-1"), legacy)
+	s.Put([]byte("bftx-2"), []byte(typed))
+
+	bftx.Id = "bftx-3"
+	bftx.Properties.BolNum = "BOL-3"
+	current, _ := bf_tx.BFTXContent(bftx)
+	if err := storage.PutBfTx(s, "bftx-3", current); err != nil {
+		t.Fatal(err.Error())
+	}
+	return s
+}
+
+func TestRecordVersions(t *testing.T) {
+	t.Log("Test on reading the record versions")
+	s := oldStore(t)
+	defer s.Close()
+
+	value, _ := s.Get([]byte("bftx-3"))
+	if !bytes.HasPrefix(value, []byte(`{"version":2,"bftx":{`)) {
+		t.Errorf("Error on the stored record: %s", value)
+	}
+	for _, id := range []string{"bftx-1", "bftx-2", "bftx-3"} {
+		if _, err := storage.GetBfTx(s, id); err != nil {
+			t.Errorf("Error on GetBfTx of %s: %v", id, err)
+		}
+	}
+	legacy, _ := storage.GetBfTx(s, "bftx-1")
+	if legacy.Properties.BolNum == "" {
+		t.Error("Error on GetBfTx of a legacy record: the BoL number is lost")
+	}
+
+	// The errors are reported instead of swallowed
+	s.Put([]byte("unknown-field"), []byte(`{"version":2,"bftx":{"Type":"object","Weight":10}}`))
+	s.Put([]byte("newer"), []byte(`{"version":9,"bftx":{}}`))
+	s.Put([]byte("broken"), []byte(`{"Type":`))
+	for _, id := range []string{"unknown-field", "newer", "broken"} {
+		if _, err := storage.GetBfTx(s, id); err == nil || !strings.HasPrefix(err.Error(), "BF_TX "+id+": ") {
+			t.Errorf("Error on GetBfTx of the %s record: %v", id, err)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Log("Test on Migrate function")
+	s := oldStore(t)
+	defer s.Close()
+	before := entries(t, s)
+
+	result, err := storage.Migrate(s, true)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.Total != 3 || result.Current != 1 || result.Migrated != 2 || !reflect.DeepEqual(result.ByVersion, map[int]int{0: 1, 1: 1}) {
+		t.Errorf("Error on the dry run: %+v", result)
+	}
+	if !reflect.DeepEqual(entries(t, s), before) {
+		t.Error("Error on the dry run: the store changed")
+	}
+
+	if result, err = storage.Migrate(s, false); err != nil || result.Migrated != 2 {
+		t.Fatalf("Error on Migrate: %+v %v", result, err)
+	}
+	for _, id := range []string{"bftx-1", "bftx-2"} {
+		value, _ := s.Get([]byte(id))
+		if !bytes.HasPrefix(value, []byte(`{"version":2,`)) {
+			t.Errorf("Error on the migrated record %s: %s", id, value)
+		}
+	}
+	if result, _ = storage.Migrate(s, true); result.Current != 3 || result.Migrated != 0 {
+		t.Errorf("Error on the dry run after Migrate: %+v", result)
+	}
+
+	// The migrated records are indexed
+	legacy, _ := storage.GetBfTx(s, "bftx-1")
+	if found, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol="+legacy.Properties.BolNum)}); !reflect.DeepEqual(found.Ids, []string{"bftx-1"}) {
+		t.Errorf("Error on Search of a migrated record: %v", found.Ids)
+	}
+}
+
+func TestMigrateLegacySignature(t *testing.T) {
+	t.Log("Test on Migrate with a legacy record signed by the first releases")
+	signed, err := ioutil.ReadFile("../../../examples/bf_tx_example_legacy_signed.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	pubkey, err := crypto.VerifyLegacy(signed)
+	if err != crypto.ErrUnverifiableSignature {
+		t.Fatalf("Error on VerifyLegacy of the legacy example: %v", err)
+	}
+	s := storage.NewMemory()
+	defer s.Close()
+	s.Put([]byte("legacy-signed"), signed)
+
+	if _, err := storage.Migrate(s, false); err != nil {
+		t.Fatal(err.Error())
+	}
+	value, _ := s.Get([]byte("legacy-signed"))
+	if bytes.Contains(value, []byte("PrivateKey")) || bytes.Contains(value, []byte(`"D":`)) {
+		t.Errorf("Error on the migrated record: the private key is stored %s", value)
+	}
+
+	// The signer is kept, the signature is weak and not verified
+	bftx, err := storage.GetBfTx(s, "legacy-signed")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if bftx.PublicKey != pubkey {
+		t.Errorf("Error on the public key of the migrated record: got %q, want %q", bftx.PublicKey, pubkey)
+	}
+	if bftx.Verified || !crypto.WeakSignature(bftx) {
+		t.Errorf("Error on the signature of the migrated record: verified %v, weak %v", bftx.Verified, crypto.WeakSignature(bftx))
+	}
+	if err := crypto.VerifySignature(bftx); err != crypto.ErrUnverifiableSignature {
+		t.Errorf("Error on VerifySignature of the migrated record: %v", err)
+	}
+}
+
+func TestMigrateFailure(t *testing.T) {
+	t.Log("Test on Migrate with a record which cannot be migrated")
+	s := oldStore(t)
+	defer s.Close()
+	s.Put([]byte("broken"), []byte(`{"Type":`))
+	before := entries(t, s)
+
+	result, err := storage.Migrate(s, true)
+	if err != nil || len(result.Failures) != 1 || result.Failures[0].Id != "broken" {
+		t.Errorf("Error on the dry run: %+v %v", result, err)
+	}
+	if _, err := storage.Migrate(s, false); err != storage.ErrMigrationFailed {
+		t.Errorf("Error on Migrate: %v", err)
+	}
+	if !reflect.DeepEqual(entries(t, s), before) {
+		t.Error("Error on a failed Migrate: the store changed")
+	}
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+// searchStore returns a store with five BF_TX shipped on consecutive days of March 2017.
+func searchStore(t *testing.T) storage.BFTXStore {
+	s := storage.NewMemory()
+	shippers := []string{"Acme Exports", "Acme Imports", "Blue Line", "acme exports", "Cargo Co"}
+	for i, shipper := range shippers {
+		bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		bftx.Id = fmt.Sprintf("bftx-%d", i+1)
+		bftx.Properties.BolNum = fmt.Sprintf("BOL-%d", i+1)
+		bftx.Properties.Shipper.Name = shipper
+		bftx.Properties.DateShipped = fmt.Sprintf("2017-03-%02d", i+1)
+		bftx.Verified = i%2 == 1
+		content, _ := bf_tx.BFTXContent(bftx)
+		if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	return s
+}
+
+func search(t *testing.T, s storage.BFTXStore, query storage.Query) storage.SearchResult {
+	result, err := storage.Search(s, query)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return result
+}
+
+func conditions(t *testing.T, exprs ...string) []storage.Condition {
+	var conditions []storage.Condition
+	for _, expr := range exprs {
+		condition, err := storage.ParseCondition(expr)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+func TestSearch(t *testing.T) {
+	t.Log("Test on Search function")
+	s := searchStore(t)
+	defer s.Close()
+
+	tests := []struct {
+		conditions []string
+		ids        []string
+	}{
+		{nil, []string{"bftx-1", "bftx-2", "bftx-3", "bftx-4", "bftx-5"}},
+		{[]string{"shipper=ACME Exports"}, []string{"bftx-1", "bftx-4"}},
+		{[]string{"shipper=acme*"}, []string{"bftx-1", "bftx-2", "bftx-4"}},
+		{[]string{"shipped=2017-03-02..2017-03-04"}, []string{"bftx-2", "bftx-3", "bftx-4"}},
+		{[]string{"shipped=2017-03-04.."}, []string{"bftx-4", "bftx-5"}},
+		{[]string{"shipped=..2017-03-01"}, []string{"bftx-1"}},
+		{[]string{"shipped=2017-03-06..2017-04-01"}, []string{}},
+		{[]string{"shipper=acme*", "state=signed"}, []string{"bftx-2", "bftx-4"}},
+		{[]string{"bol=BOL-3"}, []string{"bftx-3"}},
+		{[]string{"bol=BOL-3", "state=signed"}, []string{}},
+		{[]string{"pol=aumel"}, []string{"bftx-1", "bftx-2", "bftx-3", "bftx-4", "bftx-5"}},
+		{[]string{"shipper=acme"}, []string{}},
+	}
+	for _, test := range tests {
+		result := search(t, s, storage.Query{Conditions: conditions(t, test.conditions...)})
+		if !reflect.DeepEqual(result.Ids, test.ids) || result.Total != len(test.ids) {
+			t.Errorf("Error on Search %v: got %v (total %d), want %v", test.conditions, result.Ids, result.Total, test.ids)
+		}
+	}
+}
+
+func TestSearchPagination(t *testing.T) {
+	t.Log("Test on the pagination of Search")
+	s := searchStore(t)
+	defer s.Close()
+
+	result := search(t, s, storage.Query{Offset: 1, Limit: 2})
+	if !reflect.DeepEqual(result.Ids, []string{"bftx-2", "bftx-3"}) || result.Total != 5 {
+		t.Errorf("Error on page 2: %v (total %d)", result.Ids, result.Total)
+	}
+	result = search(t, s, storage.Query{Offset: 4, Limit: 2})
+	if !reflect.DeepEqual(result.Ids, []string{"bftx-5"}) {
+		t.Errorf("Error on the last page: %v", result.Ids)
+	}
+	result = search(t, s, storage.Query{Offset: 10, Limit: 2})
+	if len(result.Ids) != 0 || result.Total != 5 {
+		t.Errorf("Error on a page after the end: %v (total %d)", result.Ids, result.Total)
+	}
+}
+
+func TestSearchUpdate(t *testing.T) {
+	t.Log("Test on the update of the field indexes")
+	s := searchStore(t)
+	defer s.Close()
+
+	bftx, err := storage.GetBfTx(s, "bftx-1")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Transmitted = true
+	bftx.Properties.Shipper.Name = "Zeta Freight"
+	content, _ := bf_tx.BFTXContent(bftx)
+	if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if result := search(t, s, storage.Query{Conditions: conditions(t, "shipper=acme exports")}); !reflect.DeepEqual(result.Ids, []string{"bftx-4"}) {
+		t.Errorf("Error on Search of the previous shipper: %v", result.Ids)
+	}
+	if result := search(t, s, storage.Query{Conditions: conditions(t, "state=transmitted")}); !reflect.DeepEqual(result.Ids, []string{"bftx-1"}) {
+		t.Errorf("Error on Search of the new state: %v", result.Ids)
+	}
+	if result := search(t, s, storage.Query{Conditions: conditions(t, "state=constructed")}); !reflect.DeepEqual(result.Ids, []string{"bftx-3", "bftx-5"}) {
+		t.Errorf("Error on Search of the previous state: %v", result.Ids)
+	}
+}
+
+func TestSearchIndexMissing(t *testing.T) {
+	t.Log("Test on Search in a DB without field indexes")
+	s := storage.NewMemory()
+	defer s.Close()
+
+	if result := search(t, s, storage.Query{}); result.Total != 0 {
+		t.Errorf("Error on Search in an empty DB: %v", result.Ids)
+	}
+	bftx, _ := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	content, _ := bf_tx.BFTXContent(bftx)
+	s.Put([]byte("bftx-1"), []byte(content))
+	if _, err := storage.Search(s, storage.Query{}); err != storage.ErrIndexMissing {
+		t.Errorf("Error on Search without index: %v", err)
+	}
+	if _, err := storage.RebuildIndex(s); err != nil {
+		t.Fatal(err.Error())
+	}
+	if result := search(t, s, storage.Query{Conditions: conditions(t, "bol="+bftx.Properties.BolNum)}); !reflect.DeepEqual(result.Ids, []string{"bftx-1"}) {
+		t.Errorf("Error on Search after RebuildIndex: %v", result.Ids)
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	t.Log("Test on ParseCondition function")
+	tests := []struct {
+		expr      string
+		condition storage.Condition
+	}{
+		{"shipper=Acme", storage.Condition{Field: "shipper", Op: storage.OpEqual, Value: "Acme"}},
+		{"Vessel=Ever*", storage.Condition{Field: "vessel", Op: storage.OpPrefix, Value: "Ever"}},
+		{"shipped=2017-01-01..2017-12-31", storage.Condition{Field: "shipped", Op: storage.OpRange, From: "2017-01-01", To: "2017-12-31"}},
+		{"shipped=..2017-12-31", storage.Condition{Field: "shipped", Op: storage.OpRange, To: "2017-12-31"}},
+	}
+	for _, test := range tests {
+		condition, err := storage.ParseCondition(test.expr)
+		if err != nil || condition != test.condition {
+			t.Errorf("Error on ParseCondition %s: got %+v %v", test.expr, condition, err)
+		}
+	}
+	for _, expr := range []string{"shipper", "=acme", "weight=10"} {
+		if _, err := storage.ParseCondition(expr); err == nil {
+			t.Errorf("Error on ParseCondition %s: no error", expr)
+		}
+	}
+}
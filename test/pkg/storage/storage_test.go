@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/leveldb"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage/storagetest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	t.Log("Test on the conformance of the in-memory store")
+	storagetest.Run(t, func(t *testing.T) storage.BFTXStore {
+		return storage.NewMemory()
+	})
+}
+
+func TestLevelDBConformance(t *testing.T) {
+	t.Log("Test on the conformance of the LevelDB store")
+	storagetest.Run(t, func(t *testing.T) storage.BFTXStore {
+		dir, err := ioutil.TempDir("", "bft-db")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		s, err := leveldb.Open(filepath.Join(dir, "db"), nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		return &removeOnClose{s, dir}
+	})
+}
+
+// removeOnClose removes the folder of the DB when the store is closed.
+type removeOnClose struct {
+	storage.BFTXStore
+	dir string
+}
+
+func (s *removeOnClose) Close() error {
+	err := s.BFTXStore.Close()
+	os.RemoveAll(s.dir)
+	return err
+}
+
+func TestOpen(t *testing.T) {
+	t.Log("Test on Open function")
+	s, err := storage.Open("memory", "", nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	s.Close()
+	if _, err := storage.Open("bolt", "", nil); err == nil {
+		t.Error("Error on Open with an unknown backend")
+	}
+	backends := storage.Backends()
+	if len(backends) != 2 || backends[0] != "leveldb" || backends[1] != "memory" {
+		t.Errorf("Error on Backends: %v", backends)
+	}
+}
+
+func TestBfTx(t *testing.T) {
+	t.Log("Test on BF_TX functions")
+	s := storage.NewMemory()
+	defer s.Close()
+
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Id = "bftx-1"
+	content, err := bf_tx.BFTXContent(bftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	stored, err := storage.GetBfTx(s, "bftx-1")
+	if err != nil || stored.Properties.BolNum != bftx.Properties.BolNum {
+		t.Error("Error on GetBfTx")
+	}
+	if _, err := storage.GetBfTx(s, "missing"); err != storage.ErrNotFound {
+		t.Errorf("Error on GetBfTx with a missing id: %v", err)
+	}
+	if total, err := storage.Total(s); err != nil || total != 1 {
+		t.Errorf("Error on Total: %d %v", total, err)
+	}
+
+	canonical, err := bf_tx.CanonicalContent(bftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if id, err := storage.Verify(s, canonical); err != nil || string(id) != "bftx-1" {
+		t.Errorf("Error on Verify: %s %v", id, err)
+	}
+	if id, err := storage.Verify(s, []byte("{}")); err != nil || id != nil {
+		t.Errorf("Error on Verify with unknown content: %s %v", id, err)
+	}
+}
+
+func TestContentIndex(t *testing.T) {
+	t.Log("Test on the content index")
+	s := storage.NewMemory()
+	defer s.Close()
+
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	canonical, err := bf_tx.CanonicalContent(bftx)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if id, err := storage.Verify(s, canonical); err != nil || id != nil {
+		t.Errorf("Error on Verify in an empty DB: %s %v", id, err)
+	}
+
+	bftx.Id = "bftx-1"
+	content, _ := bf_tx.BFTXContent(bftx)
+	if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Signing or transmitting does not change the canonical content
+	bftx.Transmitted = true
+	content, _ = bf_tx.BFTXContent(bftx)
+	if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+		t.Fatal(err.Error())
+	}
+	if id, err := storage.Verify(s, canonical); err != nil || string(id) != "bftx-1" {
+		t.Errorf("Error on Verify: %s %v", id, err)
+	}
+
+	// A new content replaces the index entry
+	bftx.Properties.BolNum = "OTHER-001"
+	content, _ = bf_tx.BFTXContent(bftx)
+	if err := storage.PutBfTx(s, bftx.Id, content); err != nil {
+		t.Fatal(err.Error())
+	}
+	if id, err := storage.Verify(s, canonical); err != nil || id != nil {
+		t.Errorf("Error on Verify with the replaced content: %s %v", id, err)
+	}
+	changed, _ := bf_tx.CanonicalContent(bftx)
+	if id, err := storage.Verify(s, changed); err != nil || string(id) != "bftx-1" {
+		t.Errorf("Error on Verify with the new content: %s %v", id, err)
+	}
+
+	// The index entries are not BF_TX
+	if total, err := storage.Total(s); err != nil || total != 1 {
+		t.Errorf("Error on Total: %d %v", total, err)
+	}
+}
+
+func TestRebuildIndex(t *testing.T) {
+	t.Log("Test on RebuildIndex function")
+	s := storage.NewMemory()
+	defer s.Close()
+
+	// A DB written before the content index existed
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Id = "bftx-1"
+	content, _ := bf_tx.BFTXContent(bftx)
+	if err := s.Put([]byte(bftx.Id), []byte(content)); err != nil {
+		t.Fatal(err.Error())
+	}
+	canonical, _ := bf_tx.CanonicalContent(bftx)
+	if _, err := storage.Verify(s, canonical); err != storage.ErrIndexMissing {
+		t.Errorf("Error on Verify without index: %v", err)
+	}
+
+	n, err := storage.RebuildIndex(s)
+	if err != nil || n != 1 {
+		t.Fatalf("Error on RebuildIndex: %d %v", n, err)
+	}
+	if id, err := storage.Verify(s, canonical); err != nil || string(id) != "bftx-1" {
+		t.Errorf("Error on Verify after RebuildIndex: %s %v", id, err)
+	}
+
+	// Rebuilding again gives the same index
+	if n, err := storage.RebuildIndex(s); err != nil || n != 1 {
+		t.Errorf("Error on second RebuildIndex: %d %v", n, err)
+	}
+	if total, _ := storage.Total(s); total != 1 {
+		t.Errorf("Error on Total after RebuildIndex: %d", total)
+	}
+}
@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/blockfreight/go-bftx/lib/app/bf_tx"
+	"github.com/blockfreight/go-bftx/lib/pkg/storage"
+)
+
+var errCrash = errors.New("simulated crash")
+
+// crashingStore fails the write of its batches, as a process which dies before the commit.
+type crashingStore struct {
+	storage.BFTXStore
+}
+
+func (s crashingStore) Batch() storage.Batch {
+	return crashingBatch{s.BFTXStore.Batch()}
+}
+
+type crashingBatch struct {
+	storage.Batch
+}
+
+func (b crashingBatch) Write() error {
+	return errCrash
+}
+
+// appendBfTx stores amendment and links the BF_TX oldId to it, as bftx append does. fail is called between the
+// two writes.
+func appendBfTx(s storage.BFTXStore, oldId string, amendment bf_tx.BF_TX, fail func() error) error {
+	return storage.Update(s, func(tx *storage.Tx) error {
+		old, err := tx.GetBfTx(oldId)
+		if err != nil {
+			return err
+		}
+		old.Amendment = amendment.Id
+		newContent, _ := bf_tx.BFTXContent(amendment)
+		oldContent, _ := bf_tx.BFTXContent(old)
+		if err := tx.PutBfTx(amendment.Id, newContent); err != nil {
+			return err
+		}
+		if err := fail(); err != nil {
+			return err
+		}
+		return tx.PutBfTx(old.Id, oldContent)
+	})
+}
+
+func amendment(t *testing.T) bf_tx.BF_TX {
+	bftx, err := bf_tx.SetBFTX("../../../examples/bf_tx_example.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	bftx.Id = "bftx-6"
+	bftx.Properties.BolNum = "BOL-6"
+	return bftx
+}
+
+// assertUnchanged checks the store still holds the five BF_TX of searchStore, without amendment.
+func assertUnchanged(t *testing.T, s storage.BFTXStore) {
+	if total, _ := storage.Total(s); total != 5 {
+		t.Errorf("Error on Total after a failed append: %d", total)
+	}
+	if old, _ := storage.GetBfTx(s, "bftx-1"); old.Amendment != "" {
+		t.Errorf("Error after a failed append: dangling amendment link %s", old.Amendment)
+	}
+	if result, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol=BOL-6")}); result.Total != 0 {
+		t.Errorf("Error after a failed append: the amendment is indexed %v", result.Ids)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Log("Test on Update function")
+	s := searchStore(t)
+	defer s.Close()
+
+	if err := appendBfTx(s, "bftx-1", amendment(t), func() error { return nil }); err != nil {
+		t.Fatal(err.Error())
+	}
+	if old, _ := storage.GetBfTx(s, "bftx-1"); old.Amendment != "bftx-6" {
+		t.Errorf("Error on the amendment link: %q", old.Amendment)
+	}
+	if result, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol=BOL-6")}); !reflect.DeepEqual(result.Ids, []string{"bftx-6"}) {
+		t.Errorf("Error on the index of the amendment: %v", result.Ids)
+	}
+}
+
+func TestUpdateFailure(t *testing.T) {
+	t.Log("Test on Update failing between the writes")
+	s := searchStore(t)
+	defer s.Close()
+
+	if err := appendBfTx(s, "bftx-1", amendment(t), func() error { return errCrash }); err != errCrash {
+		t.Errorf("Error on Update: got %v, want the error of fn", err)
+	}
+	assertUnchanged(t, s)
+}
+
+func TestUpdateCrash(t *testing.T) {
+	t.Log("Test on Update failing on the commit")
+	s := searchStore(t)
+	defer s.Close()
+
+	if err := appendBfTx(crashingStore{s}, "bftx-1", amendment(t), func() error { return nil }); err != errCrash {
+		t.Errorf("Error on Update: got %v, want the error of the commit", err)
+	}
+	assertUnchanged(t, s)
+}
+
+func TestUpdateReadOwnWrites(t *testing.T) {
+	t.Log("Test on the reads of a transaction")
+	s := searchStore(t)
+	defer s.Close()
+
+	err := storage.Update(s, func(tx *storage.Tx) error {
+		bftx, err := tx.GetBfTx("bftx-1")
+		if err != nil {
+			return err
+		}
+		bftx.Properties.BolNum = "BOL-10"
+		content, _ := bf_tx.BFTXContent(bftx)
+		if err := tx.PutBfTx(bftx.Id, content); err != nil {
+			return err
+		}
+
+		// The transaction sees its write, the store does not yet
+		if staged, _ := tx.GetBfTx("bftx-1"); staged.Properties.BolNum != "BOL-10" {
+			t.Errorf("Error on GetBfTx of a staged BF_TX: %s", staged.Properties.BolNum)
+		}
+		if stored, _ := storage.GetBfTx(s, "bftx-1"); stored.Properties.BolNum != "BOL-1" {
+			t.Errorf("Error on GetBfTx before the commit: %s", stored.Properties.BolNum)
+		}
+
+		bftx.Properties.BolNum = "BOL-11"
+		content, _ = bf_tx.BFTXContent(bftx)
+		return tx.PutBfTx(bftx.Id, content)
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// Only the last write of the BF_TX is indexed
+	for bol, want := range map[string]int{"BOL-1": 0, "BOL-10": 0, "BOL-11": 1} {
+		if result, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol="+bol)}); result.Total != want {
+			t.Errorf("Error on Search of %s: %v", bol, result.Ids)
+		}
+	}
+	if err := storage.Update(s, func(tx *storage.Tx) error { return tx.PutBfTx("idx/content/x", "{}") }); err == nil {
+		t.Error("Error on PutBfTx with an index key as id")
+	}
+}
+
+func TestReplaceBfTx(t *testing.T) {
+	t.Log("Test on ReplaceBfTx function")
+	s := searchStore(t)
+	defer s.Close()
+
+	old, err := storage.GetBfTx(s, "bftx-1")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	transmitted := old
+	transmitted.Transmitted = true
+	content, _ := bf_tx.BFTXContent(transmitted)
+
+	// A commit failing after the BF_TX was delivered leaves it as it was read, so it can be replaced again
+	if err := storage.ReplaceBfTx(crashingStore{s}, old, content); err != errCrash {
+		t.Errorf("Error on ReplaceBfTx: got %v, want the error of the commit", err)
+	}
+	if stored, _ := storage.GetBfTx(s, "bftx-1"); stored.Transmitted {
+		t.Error("Error on ReplaceBfTx: the failed replace was written")
+	}
+	if err := storage.ReplaceBfTx(s, old, content); err != nil {
+		t.Fatal(err.Error())
+	}
+	if stored, _ := storage.GetBfTx(s, "bftx-1"); !stored.Transmitted {
+		t.Error("Error on ReplaceBfTx: the BF_TX was not replaced")
+	}
+
+	// The BF_TX changed since old was read
+	changed := old
+	changed.Properties.BolNum = "BOL-10"
+	content, _ = bf_tx.BFTXContent(changed)
+	if err := storage.ReplaceBfTx(s, old, content); err != storage.ErrChanged {
+		t.Errorf("Error on ReplaceBfTx of a changed BF_TX: got %v, want ErrChanged", err)
+	}
+	if result, _ := storage.Search(s, storage.Query{Conditions: conditions(t, "bol=BOL-10")}); result.Total != 0 {
+		t.Errorf("Error on ReplaceBfTx of a changed BF_TX: it was written %v", result.Ids)
+	}
+	if err := storage.ReplaceBfTx(s, amendment(t), content); err != storage.ErrNotFound {
+		t.Errorf("Error on ReplaceBfTx of a missing BF_TX: got %v, want ErrNotFound", err)
+	}
+}